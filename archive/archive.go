@@ -0,0 +1,135 @@
+// Package archive contains functions for reading files from tar and zip archives
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v3/compress"
+	"github.com/pkg/errors"
+)
+
+var (
+	zipMagic      = []byte("PK\x03\x04")
+	emptyZipMagic = []byte("PK\x05\x06")
+)
+
+// List - list the names of the regular files in the archive, in lexical
+// order. The archive may be a zip file, or a tar file (optionally compressed
+// with gzip or Zstandard).
+func List(in []byte) ([]string, error) {
+	names := []string{}
+	err := walk(in, func(name string, _ func() ([]byte, error)) (bool, error) {
+		names = append(names, name)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Read - read the named file from the archive. See List for the supported
+// archive formats.
+func Read(in []byte, name string) ([]byte, error) {
+	name = cleanName(name)
+	var out []byte
+	err := walk(in, func(n string, read func() ([]byte, error)) (bool, error) {
+		if n != name {
+			return false, nil
+		}
+		var err error
+		out, err = read()
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		return nil, errors.Errorf("file %q not found in archive", name)
+	}
+	return out, nil
+}
+
+// walk - call fn for each regular file in the archive, until it returns true
+// or an error
+func walk(in []byte, fn func(name string, read func() ([]byte, error)) (bool, error)) error {
+	switch {
+	case bytes.HasPrefix(in, zipMagic), bytes.HasPrefix(in, emptyZipMagic):
+		return walkZip(in, fn)
+	case bytes.HasPrefix(in, compress.GzipMagic):
+		b, err := compress.Gunzip(in)
+		if err != nil {
+			return err
+		}
+		return walkTar(b, fn)
+	case bytes.HasPrefix(in, compress.ZstdMagic):
+		b, err := compress.Unzstd(in)
+		if err != nil {
+			return err
+		}
+		return walkTar(b, fn)
+	default:
+		return walkTar(in, fn)
+	}
+}
+
+func walkZip(in []byte, fn func(string, func() ([]byte, error)) (bool, error)) error {
+	zr, err := zip.NewReader(bytes.NewReader(in), int64(len(in)))
+	if err != nil {
+		return errors.Wrap(err, "failed to read zip archive")
+	}
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		f := f
+		done, err := fn(cleanName(f.Name), func() ([]byte, error) {
+			r, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			// nolint: errcheck
+			defer r.Close()
+			return ioutil.ReadAll(r)
+		})
+		if done || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkTar(in []byte, fn func(string, func() ([]byte, error)) (bool, error)) error {
+	tr := tar.NewReader(bytes.NewReader(in))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read tar archive")
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		done, err := fn(cleanName(hdr.Name), func() ([]byte, error) {
+			return ioutil.ReadAll(tr)
+		})
+		if done || err != nil {
+			return err
+		}
+	}
+}
+
+// cleanName - normalize names like "./foo/bar" and "/foo/bar" to "foo/bar"
+func cleanName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
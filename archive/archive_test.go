@@ -0,0 +1,97 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v3/compress"
+	"github.com/stretchr/testify/assert"
+)
+
+var testFiles = []struct {
+	name, content string
+}{
+	{"./config/app.yaml", "name: app\n"},
+	{"README.md", "# hello\n"},
+	{"config/db.json", `{"host":"localhost"}`},
+}
+
+func makeTar(t *testing.T) []byte {
+	buf := &bytes.Buffer{}
+	w := tar.NewWriter(buf)
+	assert.NoError(t, w.WriteHeader(&tar.Header{Name: "config/", Typeflag: tar.TypeDir, Mode: 0755}))
+	for _, f := range testFiles {
+		assert.NoError(t, w.WriteHeader(&tar.Header{Name: f.name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(f.content))}))
+		_, err := w.Write([]byte(f.content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.WriteHeader(&tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "README.md"}))
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func makeZip(t *testing.T) []byte {
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	_, err := w.Create("config/")
+	assert.NoError(t, err)
+	for _, f := range testFiles {
+		fw, err := w.Create(f.name)
+		assert.NoError(t, err)
+		_, err = fw.Write([]byte(f.content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestListAndRead(t *testing.T) {
+	tarball := makeTar(t)
+	gz, err := compress.Gzip(tarball, -1)
+	assert.NoError(t, err)
+	zst, err := compress.Zstd(tarball, 0)
+	assert.NoError(t, err)
+
+	archives := map[string][]byte{
+		"tar":     tarball,
+		"tar.gz":  gz,
+		"tar.zst": zst,
+		"zip":     makeZip(t),
+	}
+	for format, a := range archives {
+		names, err := List(a)
+		assert.NoError(t, err, format)
+		assert.Equal(t, []string{"README.md", "config/app.yaml", "config/db.json"}, names, format)
+
+		out, err := Read(a, "config/app.yaml")
+		assert.NoError(t, err, format)
+		assert.Equal(t, "name: app\n", string(out), format)
+
+		out, err = Read(a, "/config/db.json")
+		assert.NoError(t, err, format)
+		assert.Equal(t, `{"host":"localhost"}`, string(out), format)
+
+		_, err = Read(a, "missing.txt")
+		assert.Error(t, err, format)
+
+		_, err = Read(a, "config")
+		assert.Error(t, err, format)
+	}
+}
+
+func TestInvalidArchives(t *testing.T) {
+	_, err := List([]byte("this is not an archive"))
+	assert.Error(t, err)
+
+	_, err = List([]byte("PK\x03\x04 truncated zip"))
+	assert.Error(t, err)
+
+	_, err = List(compress.GzipMagic)
+	assert.Error(t, err)
+
+	names, err := List(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+}
@@ -0,0 +1,143 @@
+package aws
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// EC2 - look up regions, availability zones, and instances with the EC2 API
+type EC2 struct {
+	client func(region string) EC2Describer
+	cache  map[string]interface{}
+}
+
+// EC2Describer - A subset of ec2iface.EC2API that we can use to describe
+// regions, availability zones, and instances
+type EC2Describer interface {
+	DescribeRegions(*ec2.DescribeRegionsInput) (*ec2.DescribeRegionsOutput, error)
+	DescribeAvailabilityZones(*ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error)
+	DescribeInstances(*ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error)
+}
+
+// NewEC2 -
+func NewEC2(options ClientOptions) *EC2 {
+	return &EC2{
+		client: func(region string) EC2Describer {
+			if region == "" {
+				return ec2.New(SDKSession())
+			}
+			return ec2.New(SDKSession(), aws.NewConfig().WithRegion(region))
+		},
+		cache: make(map[string]interface{}),
+	}
+}
+
+// Regions - the names of the regions enabled for the account, in lexical order
+func (e *EC2) Regions() ([]string, error) {
+	if cached, ok := e.cache["DescribeRegions"]; ok {
+		return cached.([]string), nil
+	}
+	out, err := e.client("").DescribeRegions(&ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, err
+	}
+	regions := make([]string, len(out.Regions))
+	for i, r := range out.Regions {
+		regions[i] = aws.StringValue(r.RegionName)
+	}
+	sort.Strings(regions)
+	e.cache["DescribeRegions"] = regions
+	return regions, nil
+}
+
+// AZs - the names of the available availability zones in the region (or the
+// current region, if empty), in lexical order
+func (e *EC2) AZs(region string) ([]string, error) {
+	key := "DescribeAvailabilityZones/" + region
+	if cached, ok := e.cache[key]; ok {
+		return cached.([]string), nil
+	}
+	out, err := e.client(region).DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("state"), Values: aws.StringSlice([]string{"available"})},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	azs := make([]string, len(out.AvailabilityZones))
+	for i, az := range out.AvailabilityZones {
+		azs[i] = aws.StringValue(az.ZoneName)
+	}
+	sort.Strings(azs)
+	e.cache[key] = azs
+	return azs, nil
+}
+
+// Instances - describe the instances matching the filters (see
+// https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeInstances.html
+// for the supported filters). Each instance is described by a map with the
+// most commonly-needed attributes.
+func (e *EC2) Instances(filters map[string][]string) ([]map[string]interface{}, error) {
+	in := &ec2.DescribeInstancesInput{}
+	names := make([]string, 0, len(filters))
+	for k := range filters {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		in.Filters = append(in.Filters, &ec2.Filter{
+			Name:   aws.String(k),
+			Values: aws.StringSlice(filters[k]),
+		})
+	}
+
+	client := e.client("")
+	instances := []map[string]interface{}{}
+	for {
+		out, err := client.DescribeInstances(in)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range out.Reservations {
+			for _, i := range r.Instances {
+				instances = append(instances, instanceMap(i))
+			}
+		}
+		if aws.StringValue(out.NextToken) == "" {
+			return instances, nil
+		}
+		in.NextToken = out.NextToken
+	}
+}
+
+func instanceMap(i *ec2.Instance) map[string]interface{} {
+	tags := map[string]string{}
+	for _, t := range i.Tags {
+		tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+	m := map[string]interface{}{
+		"InstanceId":       aws.StringValue(i.InstanceId),
+		"InstanceType":     aws.StringValue(i.InstanceType),
+		"ImageId":          aws.StringValue(i.ImageId),
+		"PrivateIpAddress": aws.StringValue(i.PrivateIpAddress),
+		"PublicIpAddress":  aws.StringValue(i.PublicIpAddress),
+		"PrivateDnsName":   aws.StringValue(i.PrivateDnsName),
+		"PublicDnsName":    aws.StringValue(i.PublicDnsName),
+		"VpcId":            aws.StringValue(i.VpcId),
+		"SubnetId":         aws.StringValue(i.SubnetId),
+		"LaunchTime":       aws.TimeValue(i.LaunchTime),
+		"Tags":             tags,
+		"State":            "",
+		"AvailabilityZone": "",
+	}
+	if i.State != nil {
+		m["State"] = aws.StringValue(i.State.Name)
+	}
+	if i.Placement != nil {
+		m["AvailabilityZone"] = aws.StringValue(i.Placement.AvailabilityZone)
+	}
+	return m
+}
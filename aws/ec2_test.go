@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+)
+
+type dummyEC2Describer struct {
+	region string
+	pages  []*ec2.DescribeInstancesOutput
+	inputs []*ec2.DescribeInstancesInput
+	err    error
+}
+
+func (d *dummyEC2Describer) DescribeRegions(*ec2.DescribeRegionsInput) (*ec2.DescribeRegionsOutput, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return &ec2.DescribeRegionsOutput{
+		Regions: []*ec2.Region{
+			{RegionName: aws.String("us-west-2")},
+			{RegionName: aws.String("eu-west-1")},
+			{RegionName: aws.String("us-east-1")},
+		},
+	}, nil
+}
+
+func (d *dummyEC2Describer) DescribeAvailabilityZones(in *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return &ec2.DescribeAvailabilityZonesOutput{
+		AvailabilityZones: []*ec2.AvailabilityZone{
+			{ZoneName: aws.String(d.region + "b")},
+			{ZoneName: aws.String(d.region + "a")},
+		},
+	}, nil
+}
+
+func (d *dummyEC2Describer) DescribeInstances(in *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	d.inputs = append(d.inputs, in)
+	out := d.pages[0]
+	d.pages = d.pages[1:]
+	return out, nil
+}
+
+func TestEC2RegionsAndAZs(t *testing.T) {
+	d := &dummyEC2Describer{}
+	e := &EC2{
+		client: func(region string) EC2Describer {
+			d.region = region
+			if region == "" {
+				d.region = "us-east-1"
+			}
+			return d
+		},
+		cache: make(map[string]interface{}),
+	}
+
+	assert.Equal(t, []string{"eu-west-1", "us-east-1", "us-west-2"}, must(e.Regions()))
+	assert.Equal(t, []string{"us-east-1a", "us-east-1b"}, must(e.AZs("")))
+	assert.Equal(t, []string{"eu-west-1a", "eu-west-1b"}, must(e.AZs("eu-west-1")))
+
+	// cached
+	d.err = errors.New("fail")
+	assert.Equal(t, []string{"eu-west-1", "us-east-1", "us-west-2"}, must(e.Regions()))
+
+	_, err := e.AZs("ca-central-1")
+	assert.Error(t, err)
+}
+
+func TestEC2Instances(t *testing.T) {
+	launch := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	d := &dummyEC2Describer{
+		pages: []*ec2.DescribeInstancesOutput{
+			{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{
+						{
+							InstanceId:       aws.String("i-1"),
+							InstanceType:     aws.String("t3.micro"),
+							PrivateIpAddress: aws.String("10.0.0.1"),
+							LaunchTime:       &launch,
+							State:            &ec2.InstanceState{Name: aws.String("running")},
+							Placement:        &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")},
+							Tags: []*ec2.Tag{
+								{Key: aws.String("Name"), Value: aws.String("web-1")},
+							},
+						},
+					}},
+				},
+				NextToken: aws.String("page2"),
+			},
+			{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{{InstanceId: aws.String("i-2")}}},
+				},
+			},
+		},
+	}
+	e := &EC2{
+		client: func(string) EC2Describer { return d },
+		cache:  make(map[string]interface{}),
+	}
+
+	out, err := e.Instances(map[string][]string{
+		"tag:Role":            {"web"},
+		"instance-state-name": {"running", "pending"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, out, 2)
+	assert.Equal(t, "i-1", out[0]["InstanceId"])
+	assert.Equal(t, "t3.micro", out[0]["InstanceType"])
+	assert.Equal(t, "10.0.0.1", out[0]["PrivateIpAddress"])
+	assert.Equal(t, "", out[0]["PublicIpAddress"])
+	assert.Equal(t, "running", out[0]["State"])
+	assert.Equal(t, "us-east-1a", out[0]["AvailabilityZone"])
+	assert.Equal(t, launch, out[0]["LaunchTime"])
+	assert.Equal(t, map[string]string{"Name": "web-1"}, out[0]["Tags"])
+	assert.Equal(t, "i-2", out[1]["InstanceId"])
+	assert.Equal(t, "", out[1]["State"])
+
+	assert.Len(t, d.inputs, 2)
+	assert.Equal(t, []*ec2.Filter{
+		{Name: aws.String("instance-state-name"), Values: aws.StringSlice([]string{"running", "pending"})},
+		{Name: aws.String("tag:Role"), Values: aws.StringSlice([]string{"web"})},
+	}, d.inputs[0].Filters)
+	assert.Equal(t, "page2", aws.StringValue(d.inputs[1].NextToken))
+
+	d.err = errors.New("fail")
+	_, err = e.Instances(nil)
+	assert.Error(t, err)
+}
@@ -139,6 +139,21 @@ func (e *Ec2Meta) Region(def ...string) (string, error) {
 	return obj.Region, nil
 }
 
+// AccountID - the ID of the account that owns the instance, or an empty string
+// when not running in EC2
+func (e *Ec2Meta) AccountID() (string, error) {
+	doc, err := e.Dynamic("instance-identity/document", `{}`)
+	if err != nil {
+		return "", err
+	}
+	obj := &InstanceDocument{}
+	err = json.Unmarshal([]byte(doc), &obj)
+	if err != nil {
+		return "", errors.Wrapf(err, "Unable to unmarshal JSON object %s", doc)
+	}
+	return obj.AccountID, nil
+}
+
 // InstanceDocument -
 type InstanceDocument struct {
 	PrivateIP        string `json:"privateIp"`
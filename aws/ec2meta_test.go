@@ -67,6 +67,16 @@ func TestRegion_KnownRegion(t *testing.T) {
 	assert.Equal(t, "us-east-1", must(ec2meta.Region()))
 }
 
+func TestAccountID(t *testing.T) {
+	server, ec2meta := MockServer(200, `{"accountId":"123456789012","region":"us-east-1"}`)
+	defer server.Close()
+
+	assert.Equal(t, "123456789012", must(ec2meta.AccountID()))
+
+	ec2meta = NewDummyEc2Meta()
+	assert.Equal(t, "", must(ec2meta.AccountID()))
+}
+
 func TestUnreachable(t *testing.T) {
 	assert.False(t, unreachable(errors.New("foo")))
 	assert.True(t, unreachable(errors.New("host is down")))
@@ -0,0 +1,276 @@
+// Package azure contains functions for reading Azure Instance Metadata
+// Service (IMDS) data
+package azure
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hairyhenderson/gomplate/v3/env"
+)
+
+// DefaultEndpoint -
+var DefaultEndpoint = "http://169.254.169.254"
+
+const (
+	apiVersion      = "2021-02-01"
+	tokenAPIVersion = "2018-02-01"
+
+	// DefaultResource - the resource to request managed identity tokens for,
+	// when not specified
+	DefaultResource = "https://management.azure.com/"
+)
+
+var (
+	co     ClientOptions
+	coInit sync.Once
+)
+
+// ClientOptions -
+type ClientOptions struct {
+	Timeout time.Duration
+}
+
+// GetClientOptions - read the timeout from AZURE_TIMEOUT, in milliseconds
+func GetClientOptions() ClientOptions {
+	coInit.Do(func() {
+		timeout := os.Getenv("AZURE_TIMEOUT")
+		if timeout == "" {
+			timeout = "500"
+		}
+
+		t, err := strconv.Atoi(timeout)
+		if err != nil {
+			panic(errors.Wrapf(err, "Invalid AZURE_TIMEOUT value '%s' - must be an integer\n", timeout))
+		}
+
+		co.Timeout = time.Duration(t) * time.Millisecond
+	})
+	return co
+}
+
+// Meta - reads from the Azure Instance Metadata Service
+type Meta struct {
+	Endpoint string
+	Client   *http.Client
+	nonAzure bool
+	cache    map[string]string
+	options  ClientOptions
+}
+
+// NewMeta -
+func NewMeta(options ClientOptions) *Meta {
+	if endpoint := env.Getenv("AZURE_META_ENDPOINT"); endpoint != "" {
+		DefaultEndpoint = endpoint
+	}
+
+	return &Meta{cache: make(map[string]string), options: options}
+}
+
+// Compute - the compute metadata for the VM
+type Compute struct {
+	Name              string `json:"name"`
+	VMID              string `json:"vmId"`
+	VMSize            string `json:"vmSize"`
+	Location          string `json:"location"`
+	Zone              string `json:"zone"`
+	ResourceGroupName string `json:"resourceGroupName"`
+	SubscriptionID    string `json:"subscriptionId"`
+	TagsList          []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"tagsList"`
+}
+
+func returnDefault(def []string) string {
+	if len(def) > 0 {
+		return def[0]
+	}
+	return ""
+}
+
+// retrieve the metadata, returning an empty string if we're not in Azure or
+// if there's a non-OK response
+func (m *Meta) retrieveMetadata(path string, query url.Values) (string, error) {
+	if m.Endpoint == "" {
+		m.Endpoint = DefaultEndpoint
+	}
+	u := m.Endpoint + path + "?" + query.Encode()
+
+	if value, ok := m.cache[u]; ok {
+		return value, nil
+	}
+
+	if m.nonAzure {
+		return "", nil
+	}
+
+	if m.Client == nil {
+		timeout := m.options.Timeout
+		if timeout == 0 {
+			timeout = 500 * time.Millisecond
+		}
+		m.Client = &http.Client{Timeout: timeout}
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata", "true")
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		// IMDS can't be reached, so we're probably not in Azure
+		m.nonAzure = true
+		return "", nil
+	}
+
+	// nolint: errcheck
+	defer resp.Body.Close()
+	if resp.StatusCode > 399 {
+		return "", nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to read response body from %s", u)
+	}
+	value := strings.TrimSpace(string(body))
+	m.cache[u] = value
+
+	return value, nil
+}
+
+// Meta - read the given instance metadata path (e.g. "compute/vmSize"). The
+// path must refer to a single value, rather than an object or array.
+func (m *Meta) Meta(path string, def ...string) (string, error) {
+	path = "/metadata/instance/" + strings.Trim(path, "/")
+	v, err := m.retrieveMetadata(path, url.Values{
+		"api-version": {apiVersion},
+		"format":      {"text"},
+	})
+	if err != nil || v == "" {
+		return returnDefault(def), err
+	}
+	return v, nil
+}
+
+// Compute - read the compute metadata. When not in Azure, all fields are
+// empty.
+func (m *Meta) Compute() (*Compute, error) {
+	v, err := m.retrieveMetadata("/metadata/instance/compute", url.Values{
+		"api-version": {apiVersion},
+	})
+	if err != nil {
+		return nil, err
+	}
+	c := &Compute{}
+	if v == "" {
+		return c, nil
+	}
+	err = json.Unmarshal([]byte(v), c)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to unmarshal JSON object %s", v)
+	}
+	return c, nil
+}
+
+func (m *Meta) computeField(fn func(*Compute) string, def []string) (string, error) {
+	c, err := m.Compute()
+	if err != nil {
+		return "", err
+	}
+	if v := fn(c); v != "" {
+		return v, nil
+	}
+	return returnDefault(def), nil
+}
+
+// VMName - the VM's name
+func (m *Meta) VMName(def ...string) (string, error) {
+	return m.computeField(func(c *Compute) string { return c.Name }, def)
+}
+
+// VMID - the VM's unique ID
+func (m *Meta) VMID(def ...string) (string, error) {
+	return m.computeField(func(c *Compute) string { return c.VMID }, def)
+}
+
+// VMSize - the VM's size (e.g. "Standard_D2s_v3")
+func (m *Meta) VMSize(def ...string) (string, error) {
+	return m.computeField(func(c *Compute) string { return c.VMSize }, def)
+}
+
+// Location - the Azure region the VM is running in
+func (m *Meta) Location(def ...string) (string, error) {
+	return m.computeField(func(c *Compute) string { return c.Location }, def)
+}
+
+// ResourceGroup - the name of the VM's resource group
+func (m *Meta) ResourceGroup(def ...string) (string, error) {
+	return m.computeField(func(c *Compute) string { return c.ResourceGroupName }, def)
+}
+
+// SubscriptionID - the ID of the VM's subscription
+func (m *Meta) SubscriptionID(def ...string) (string, error) {
+	return m.computeField(func(c *Compute) string { return c.SubscriptionID }, def)
+}
+
+// Tags - the VM's tags
+func (m *Meta) Tags() (map[string]string, error) {
+	c, err := m.Compute()
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string, len(c.TagsList))
+	for _, t := range c.TagsList {
+		tags[t.Name] = t.Value
+	}
+	return tags, nil
+}
+
+// Tag - the value of the given tag
+func (m *Meta) Tag(name string, def ...string) (string, error) {
+	tags, err := m.Tags()
+	if err != nil {
+		return "", err
+	}
+	if v, ok := tags[name]; ok {
+		return v, nil
+	}
+	return returnDefault(def), nil
+}
+
+// Token - get an access token for the given resource (DefaultResource when
+// empty) from the VM's managed identity
+func (m *Meta) Token(resource string) (string, error) {
+	if resource == "" {
+		resource = DefaultResource
+	}
+	v, err := m.retrieveMetadata("/metadata/identity/oauth2/token", url.Values{
+		"api-version": {tokenAPIVersion},
+		"resource":    {resource},
+	})
+	if err != nil {
+		return "", err
+	}
+	if v == "" {
+		return "", errors.Errorf("failed to get a managed identity token for %s", resource)
+	}
+	tok := struct {
+		AccessToken string `json:"access_token"`
+	}{}
+	err = json.Unmarshal([]byte(v), &tok)
+	if err != nil {
+		return "", errors.Wrap(err, "Unable to unmarshal managed identity token")
+	}
+	return tok.AccessToken, nil
+}
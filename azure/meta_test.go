@@ -0,0 +1,111 @@
+package azure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func must(r interface{}, err error) interface{} {
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func mockServer(values map[string]string) (*httptest.Server, *Meta) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		key := r.URL.Path
+		if res := r.URL.Query().Get("resource"); res != "" {
+			key += "?" + res
+		}
+		v, ok := values[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// nolint: errcheck
+		w.Write([]byte(v))
+	}))
+	return server, &Meta{Endpoint: server.URL, cache: make(map[string]string)}
+}
+
+const computeJSON = `{
+  "location": "westeurope",
+  "name": "web-1",
+  "resourceGroupName": "my-rg",
+  "subscriptionId": "8d10da13-8125-4ba9-a717-bf7490507b3d",
+  "tags": "env:prod;role:web",
+  "tagsList": [
+    {"name": "env", "value": "prod"},
+    {"name": "role", "value": "web"}
+  ],
+  "vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+  "vmSize": "Standard_D2s_v3"
+}`
+
+func TestMeta(t *testing.T) {
+	server, m := mockServer(map[string]string{
+		"/metadata/instance/compute":                                    computeJSON,
+		"/metadata/instance/compute/vmSize":                             "Standard_D2s_v3\n",
+		"/metadata/identity/oauth2/token?https://management.azure.com/": `{"access_token":"mgmt-token","token_type":"Bearer"}`,
+		"/metadata/identity/oauth2/token?https://vault.azure.net":       `{"access_token":"vault-token","token_type":"Bearer"}`,
+	})
+	defer server.Close()
+
+	assert.Equal(t, "web-1", must(m.VMName()))
+	assert.Equal(t, "02aab8a4-74ef-476e-8182-f6d2ba4166a6", must(m.VMID()))
+	assert.Equal(t, "Standard_D2s_v3", must(m.VMSize()))
+	assert.Equal(t, "westeurope", must(m.Location()))
+	assert.Equal(t, "my-rg", must(m.ResourceGroup()))
+	assert.Equal(t, "8d10da13-8125-4ba9-a717-bf7490507b3d", must(m.SubscriptionID()))
+	assert.Equal(t, map[string]string{"env": "prod", "role": "web"}, must(m.Tags()))
+	assert.Equal(t, "web", must(m.Tag("role")))
+	assert.Equal(t, "none", must(m.Tag("missing", "none")))
+	assert.Equal(t, "Standard_D2s_v3", must(m.Meta("compute/vmSize")))
+	assert.Equal(t, "Standard_D2s_v3", must(m.Meta("/compute/vmSize/")))
+	assert.Equal(t, "default", must(m.Meta("compute/missing", "default")))
+	assert.Equal(t, "mgmt-token", must(m.Token("")))
+	assert.Equal(t, "vault-token", must(m.Token("https://vault.azure.net")))
+
+	_, err := m.Token("https://storage.azure.com/")
+	assert.Error(t, err)
+}
+
+func TestMeta_BadJSON(t *testing.T) {
+	server, m := mockServer(map[string]string{
+		"/metadata/instance/compute": "not json",
+	})
+	defer server.Close()
+
+	_, err := m.VMName()
+	assert.Error(t, err)
+}
+
+func TestMeta_NonAzure(t *testing.T) {
+	m := NewMeta(ClientOptions{})
+	m.nonAzure = true
+
+	assert.Equal(t, "foo", must(m.VMName("foo")))
+	assert.Equal(t, "", must(m.ResourceGroup()))
+	assert.Equal(t, "bar", must(m.Meta("compute/vmSize", "bar")))
+	assert.Equal(t, map[string]string{}, must(m.Tags()))
+	_, err := m.Token("")
+	assert.Error(t, err)
+}
+
+func TestMeta_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	url := server.URL
+	server.Close()
+	m := &Meta{Endpoint: url, cache: make(map[string]string)}
+
+	assert.Equal(t, "foo", must(m.SubscriptionID("foo")))
+	assert.True(t, m.nonAzure)
+}
@@ -1,11 +1,19 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/hairyhenderson/gomplate/v3/conv"
+	"github.com/hairyhenderson/gomplate/v3/data"
 	"github.com/hairyhenderson/gomplate/v3/env"
 	"github.com/hairyhenderson/gomplate/v3/internal/config"
 
@@ -19,6 +27,14 @@ const (
 	defaultConfigFile = ".gomplate.yaml"
 )
 
+// default config files are looked for in this order
+var defaultConfigFiles = []string{
+	defaultConfigFile,
+	".gomplate.yml",
+	".gomplate.json",
+	".gomplate.toml",
+}
+
 var fs = afero.NewOsFs()
 
 // loadConfig is intended to be called before command execution. It:
@@ -28,39 +44,58 @@ var fs = afero.NewOsFs()
 // - validates the final config
 // - converts the config to a *gomplate.Config for further use (TODO: eliminate this part)
 func loadConfig(cmd *cobra.Command, args []string) (*config.Config, error) {
+	cfg, _, err := loadConfigWithProvenance(cmd, args)
+	return cfg, err
+}
+
+// loadConfigWithProvenance - like loadConfig, but also records where each
+// setting came from
+func loadConfigWithProvenance(cmd *cobra.Command, args []string) (*config.Config, *config.Provenance, error) {
 	ctx := cmd.Context()
+	prov := &config.Provenance{}
 	flagConfig, err := cobraConfig(cmd, args)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	cfg, err := readConfigFile(cmd)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if cfg == nil {
 		cfg = flagConfig
 	} else {
+		cfgFile, _ := pickConfigFile(cmd)
+		prov.Track(cfg, "config file "+cfgFile)
 		cfg = cfg.MergeFrom(flagConfig)
 	}
+	prov.Track(cfg, "flag")
 
 	cfg, err = applyEnvVars(ctx, cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	prov.Track(cfg, "environment variable")
 
 	// reset defaults before validation
 	cfg.ApplyDefaults()
+	prov.Track(cfg, "default")
 
 	err = cfg.Validate()
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate merged config: %w\n%+v", err, cfg)
+		return nil, nil, fmt.Errorf("failed to validate merged config: %w\n%s", err, cfg.StringWithProvenance(prov))
 	}
-	return cfg, nil
+	return cfg, prov, nil
 }
 
 func pickConfigFile(cmd *cobra.Command) (cfgFile string, required bool) {
 	cfgFile = defaultConfigFile
+	for _, f := range defaultConfigFiles {
+		if _, err := fs.Stat(f); err == nil {
+			cfgFile = f
+			break
+		}
+	}
 	if c := env.Getenv("GOMPLATE_CONFIG"); c != "" {
 		cfgFile = c
 		required = true
@@ -73,7 +108,26 @@ func pickConfigFile(cmd *cobra.Command) (cfgFile string, required bool) {
 	return cfgFile, required
 }
 
+// pickProfile - the config file profile to use, from the --profile flag or the
+// GOMPLATE_PROFILE environment variable
+func pickProfile(cmd *cobra.Command) string {
+	if p, _ := getString(cmd, "profile"); p != "" {
+		return p
+	}
+	return env.Getenv("GOMPLATE_PROFILE")
+}
+
 func readConfigFile(cmd *cobra.Command) (cfg *config.Config, err error) {
+	strict, err := getBool(cmd, "strict-config")
+	if err != nil {
+		return nil, err
+	}
+	return readConfigFileStrict(cmd, strict)
+}
+
+// readConfigFileStrict - like readConfigFile, but the strictness is given
+// rather than read from the --strict-config flag
+func readConfigFileStrict(cmd *cobra.Command, strict bool) (cfg *config.Config, err error) {
 	ctx := cmd.Context()
 	if ctx == nil {
 		ctx = context.Background()
@@ -82,24 +136,75 @@ func readConfigFile(cmd *cobra.Command) (cfg *config.Config, err error) {
 
 	cfgFile, configRequired := pickConfigFile(cmd)
 
-	f, err := fs.Open(cfgFile)
+	hdr, err := getStringSlice(cmd, "datasource-header")
+	if err != nil {
+		return nil, err
+	}
+	hdrCfg := &config.Config{}
+	err = hdrCfg.ParseDataSourceFlags(nil, nil, hdr)
+	if err != nil {
+		return nil, err
+	}
+	open := configOpener(hdrCfg.ExtraHeaders)
+
+	f, err := open(cfgFile)
 	if err != nil {
 		if configRequired {
 			return cfg, fmt.Errorf("config file requested, but couldn't be opened: %w", err)
 		}
+		if p := pickProfile(cmd); p != "" {
+			return cfg, fmt.Errorf("profile %q requested, but config file couldn't be opened: %w", p, err)
+		}
 		return nil, nil
 	}
+	// nolint: errcheck
+	defer f.Close()
 
-	cfg, err = config.Parse(f)
+	if strict {
+		cfg, err = config.ParseStrict(f, config.FormatFromName(cfgFile))
+	} else {
+		cfg, err = config.ParseFormat(f, config.FormatFromName(cfgFile))
+	}
 	if err != nil && configRequired {
 		return cfg, fmt.Errorf("config file requested, but couldn't be parsed: %w", err)
 	}
+	if err == nil {
+		cfg, err = cfg.ResolveIncludes(cfgFile, open, strict)
+		if err != nil {
+			return nil, err
+		}
+		cfg, err = cfg.ApplyProfile(pickProfile(cmd))
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	log.Debug().Str("cfgFile", cfgFile).Msg("using config file")
 
 	return cfg, err
 }
 
+// configOpener - returns a function to open config files by path, or by any
+// URL supported by datasources. Headers set with --datasource-header are used
+// when the alias is the config file's URL.
+func configOpener(headers map[string]http.Header) config.OpenFunc {
+	return func(name string) (io.ReadCloser, error) {
+		u, err := url.Parse(name)
+		// single-letter schemes are Windows drive letters
+		if err != nil || len(u.Scheme) <= 1 {
+			return fs.Open(name)
+		}
+		if u.Scheme == "file" {
+			return fs.Open(filepath.FromSlash(u.Path))
+		}
+		b, err := data.ReadURL(u, headers[name])
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+}
+
 // cobraConfig - initialize a config from the commandline options
 func cobraConfig(cmd *cobra.Command, args []string) (cfg *config.Config, err error) {
 	cfg = &config.Config{}
@@ -135,6 +240,18 @@ func cobraConfig(cmd *cobra.Command, args []string) (cfg *config.Config, err err
 	if err != nil {
 		return nil, err
 	}
+	cfg.ValuesFiles, err = getStringSlice(cmd, "values")
+	if err != nil {
+		return nil, err
+	}
+	cfg.SetValues, err = getStringArray(cmd, "set")
+	if err != nil {
+		return nil, err
+	}
+	cfg.SetFiles, err = getStringArray(cmd, "set-file")
+	if err != nil {
+		return nil, err
+	}
 	cfg.OutputDir, err = getString(cmd, "output-dir")
 	if err != nil {
 		return nil, err
@@ -148,6 +265,15 @@ func cobraConfig(cmd *cobra.Command, args []string) (cfg *config.Config, err err
 		return nil, err
 	}
 
+	cfg.MissingKey, err = getString(cmd, "missing-key")
+	if err != nil {
+		return nil, err
+	}
+	cfg.MissingKeyDefault, err = getString(cmd, "missing-key-default")
+	if err != nil {
+		return nil, err
+	}
+
 	if len(args) > 0 {
 		cfg.PostExec = args
 	}
@@ -156,6 +282,38 @@ func cobraConfig(cmd *cobra.Command, args []string) (cfg *config.Config, err err
 	if err != nil {
 		return nil, err
 	}
+	cfg.PostExecDir, err = getString(cmd, "exec-dir")
+	if err != nil {
+		return nil, err
+	}
+	execEnv, err := getStringSlice(cmd, "exec-env")
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range execEnv {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid --exec-env value %q: must be in name=value form", e)
+		}
+		if cfg.PostExecEnv == nil {
+			cfg.PostExecEnv = map[string]string{}
+		}
+		cfg.PostExecEnv[parts[0]] = parts[1]
+	}
+	cfg.PostExecShell, err = getBool(cmd, "exec-shell")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.EnableExec, err = getBool(cmd, "enable-exec")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.EnableSprig, err = getBool(cmd, "enable-sprig")
+	if err != nil {
+		return nil, err
+	}
 
 	cfg.LDelim, err = getString(cmd, "left-delim")
 	if err != nil {
@@ -201,6 +359,13 @@ func getStringSlice(cmd *cobra.Command, flag string) (s []string, err error) {
 	return s, err
 }
 
+func getStringArray(cmd *cobra.Command, flag string) (s []string, err error) {
+	if cmd.Flag(flag) != nil && cmd.Flag(flag).Changed {
+		s, err = cmd.Flags().GetStringArray(flag)
+	}
+	return s, err
+}
+
 func getString(cmd *cobra.Command, flag string) (s string, err error) {
 	if cmd.Flag(flag) != nil && cmd.Flag(flag).Changed {
 		s, err = cmd.Flags().GetString(flag)
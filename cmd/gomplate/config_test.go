@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
@@ -56,6 +59,110 @@ func TestReadConfigFile(t *testing.T) {
 
 	_, err = readConfigFile(cmd)
 	assert.Error(t, err)
+
+	cmd = &cobra.Command{}
+	cmd.Flags().String("config", defaultConfigFile, "foo")
+	cmd.ParseFlags([]string{"--config", "conf/main.yaml"})
+
+	fs.MkdirAll("conf", 0755)
+	afero.WriteFile(fs, "conf/main.yaml", []byte("include: [base.yaml]\nin: hello world\n"), 0644)
+	afero.WriteFile(fs, "conf/base.yaml", []byte("in: base\nleftDelim: ((\n"), 0644)
+
+	cfg, err = readConfigFile(cmd)
+	assert.NoError(t, err)
+	assert.EqualValues(t, &config.Config{Input: "hello world", LDelim: "(("}, cfg)
+
+	cmd = &cobra.Command{}
+	cmd.Flags().String("config", defaultConfigFile, "foo")
+	cmd.Flags().String("profile", "", "foo")
+	cmd.ParseFlags([]string{"--config", "profiles.yaml"})
+	afero.WriteFile(fs, "profiles.yaml", []byte(`in: hello
+profiles:
+  dev:
+    in: hello dev
+  prod:
+    in: hello prod
+`), 0644)
+
+	os.Setenv("GOMPLATE_PROFILE", "dev")
+	defer os.Unsetenv("GOMPLATE_PROFILE")
+	cfg, err = readConfigFile(cmd)
+	assert.NoError(t, err)
+	assert.EqualValues(t, &config.Config{Input: "hello dev"}, cfg)
+	os.Unsetenv("GOMPLATE_PROFILE")
+
+	cmd.ParseFlags([]string{"--profile", "prod"})
+	cfg, err = readConfigFile(cmd)
+	assert.NoError(t, err)
+	assert.EqualValues(t, &config.Config{Input: "hello prod"}, cfg)
+
+	cmd.ParseFlags([]string{"--profile", "qa"})
+	_, err = readConfigFile(cmd)
+	assert.Error(t, err)
+
+	cmd = &cobra.Command{}
+	cmd.Flags().String("config", defaultConfigFile, "foo")
+	cmd.ParseFlags([]string{"--config", "config.toml"})
+	afero.WriteFile(fs, "config.toml", []byte(`in = "hello toml"`), 0644)
+
+	cfg, err = readConfigFile(cmd)
+	assert.NoError(t, err)
+	assert.EqualValues(t, &config.Config{Input: "hello toml"}, cfg)
+
+	cmd = &cobra.Command{}
+	cmd.Flags().String("config", defaultConfigFile, "foo")
+	cmd.Flags().Bool("strict-config", false, "foo")
+	cmd.ParseFlags([]string{"--config", "typo.yaml"})
+	afero.WriteFile(fs, "typo.yaml", []byte("in: hello\noutputdir: out\n"), 0644)
+
+	cfg, err = readConfigFile(cmd)
+	assert.NoError(t, err)
+	assert.EqualValues(t, &config.Config{Input: "hello"}, cfg)
+
+	cmd.ParseFlags([]string{"--strict-config"})
+	_, err = readConfigFile(cmd)
+	assert.Error(t, err)
+}
+
+func TestConfigOpener(t *testing.T) {
+	fs = afero.NewMemMapFs()
+	defer func() { fs = afero.NewOsFs() }()
+	afero.WriteFile(fs, "/tmp/local.yaml", []byte("in: local"), 0644)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer abcd" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("in: remote"))
+	}))
+	defer srv.Close()
+
+	remote := srv.URL + "/config.yaml"
+	open := configOpener(map[string]http.Header{
+		remote: {"Authorization": {"Bearer abcd"}},
+	})
+
+	for name, expected := range map[string]string{
+		"/tmp/local.yaml":        "in: local",
+		"file:///tmp/local.yaml": "in: local",
+		remote:                   "in: remote",
+	} {
+		f, err := open(name)
+		if !assert.NoError(t, err, name) {
+			continue
+		}
+		b, err := ioutil.ReadAll(f)
+		assert.NoError(t, err, name)
+		assert.Equal(t, expected, string(b), name)
+		f.Close()
+	}
+
+	_, err := open(srv.URL + "/other.yaml")
+	assert.Error(t, err)
+
+	_, err = open("missing.yaml")
+	assert.Error(t, err)
 }
 
 func TestLoadConfig(t *testing.T) {
@@ -117,6 +224,29 @@ func TestLoadConfig(t *testing.T) {
 	assert.EqualValues(t, expected, out)
 }
 
+func TestLoadConfigWithProvenance(t *testing.T) {
+	fs = afero.NewMemMapFs()
+	defer func() { fs = afero.NewOsFs() }()
+	_ = afero.WriteFile(fs, ".gomplate.yaml", []byte("inputDir: in/\noutputDir: out/\n"), 0644)
+	os.Setenv("GOMPLATE_SUPPRESS_EMPTY", "true")
+	defer os.Unsetenv("GOMPLATE_SUPPRESS_EMPTY")
+
+	cmd := &cobra.Command{}
+	cmd.Args = optionalExecArgs
+	cmd.Flags().String("config", defaultConfigFile, "...")
+	cmd.Flags().String("output-dir", ".", "...")
+	cmd.ParseFlags([]string{"--output-dir", "build/"})
+
+	cfg, prov, err := loadConfigWithProvenance(cmd, cmd.Flags().Args())
+	assert.NoError(t, err)
+	assert.Equal(t, "build/", cfg.OutputDir)
+	assert.Equal(t, "config file .gomplate.yaml", prov.Source("inputDir"))
+	assert.Equal(t, "flag", prov.Source("outputDir"))
+	assert.Equal(t, "environment variable", prov.Source("suppressEmpty"))
+	assert.Equal(t, "default", prov.Source("leftDelim"))
+	assert.Equal(t, "", prov.Source("outputMap"))
+}
+
 func TestCobraConfig(t *testing.T) {
 	t.Parallel()
 	cmd := &cobra.Command{}
@@ -141,6 +271,44 @@ func TestCobraConfig(t *testing.T) {
 	}, cfg)
 }
 
+func TestCobraConfigExtraFlags(t *testing.T) {
+	t.Parallel()
+	cmd := &cobra.Command{}
+	cmd.Flags().StringSlice("values", nil, "...")
+	cmd.Flags().String("missing-key", "", "...")
+	cmd.Flags().String("missing-key-default", "", "...")
+	cmd.Flags().String("exec-dir", "", "...")
+	cmd.Flags().StringSlice("exec-env", nil, "...")
+	cmd.Flags().Bool("exec-shell", false, "...")
+	cmd.Flags().StringArray("set", nil, "...")
+	cmd.Flags().StringArray("set-file", nil, "...")
+	cmd.ParseFlags([]string{
+		"--values", "a.yaml", "--values", "b.json",
+		"--missing-key", "default", "--missing-key-default", "N/A",
+		"--exec-dir", "/tmp", "--exec-env", "FOO=bar,BAZ=a=b", "--exec-shell",
+		"--set", "a.b=c,d={e,f}", "--set", "g=h", "--set-file", "cert=cert.pem",
+	})
+
+	cfg, err := cobraConfig(cmd, cmd.Flags().Args())
+	assert.NoError(t, err)
+	assert.EqualValues(t, &config.Config{
+		ValuesFiles:       []string{"a.yaml", "b.json"},
+		SetValues:         []string{"a.b=c,d={e,f}", "g=h"},
+		SetFiles:          []string{"cert=cert.pem"},
+		MissingKey:        "default",
+		MissingKeyDefault: "N/A",
+		PostExecDir:       "/tmp",
+		PostExecEnv:       map[string]string{"FOO": "bar", "BAZ": "a=b"},
+		PostExecShell:     true,
+	}, cfg)
+
+	cmd = &cobra.Command{}
+	cmd.Flags().StringSlice("exec-env", nil, "...")
+	cmd.ParseFlags([]string{"--exec-env", "FOO"})
+	_, err = cobraConfig(cmd, cmd.Flags().Args())
+	assert.Error(t, err)
+}
+
 func TestProcessIncludes(t *testing.T) {
 	t.Parallel()
 	data := []struct {
@@ -166,6 +334,13 @@ func TestPickConfigFile(t *testing.T) {
 	assert.False(t, req)
 	assert.Equal(t, defaultConfigFile, cf)
 
+	fs = afero.NewMemMapFs()
+	defer func() { fs = afero.NewOsFs() }()
+	afero.WriteFile(fs, ".gomplate.json", []byte(`{}`), 0644)
+	cf, req = pickConfigFile(cmd)
+	assert.False(t, req)
+	assert.Equal(t, ".gomplate.json", cf)
+
 	os.Setenv("GOMPLATE_CONFIG", "foo.yaml")
 	defer os.Unsetenv("GOMPLATE_CONFIG")
 	cf, req = pickConfigFile(cmd)
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hairyhenderson/gomplate/v3/internal/config"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// configTemplate - the commented config file written by 'config init'
+const configTemplate = `# gomplate config file - see https://docs.gomplate.ca/config/ for details.
+# Command-line arguments take precedence over settings in this file.
+
+# Input: one of 'in' (an inline template), 'inputFiles', or 'inputDir'
+# in: hello {{ .data.name }}
+# inputFiles: [in.tmpl]
+inputDir: templates/
+
+# Output: 'outputFiles' (with 'inputFiles'), or 'outputDir' or 'outputMap'
+# (with 'inputDir')
+# outputFiles: [out.txt]
+outputDir: out/
+# outputMap: out/{{ .in | strings.ReplaceAll ".tmpl" "" }}
+
+# glob patterns of files in the inputDir to skip
+# excludes: ['*.bak']
+
+# file mode for output files (default: same as the input)
+# chmod: "644"
+
+# datasources, referenced in templates with e.g. 'ds "data"'. URLs can include
+# environment variables, like ${API_HOST}.
+# datasources:
+#   data:
+#     url: https://example.com/data.json
+#     header:
+#       Authorization: ["Bearer ${API_TOKEN}"]
+
+# datasources to load into the context, referenced as e.g. '.data'
+# context:
+#   data:
+#     url: data.yaml
+
+# nested templates
+# templates:
+#   - partials/
+
+# external commands callable as functions
+# plugins:
+#   echo: /bin/echo
+#   lookup:
+#     cmd: lookup.sh
+#     args: [--quiet]
+#     timeout: 10s
+
+# named overrides, selected with --profile or $GOMPLATE_PROFILE
+# profiles:
+#   prod:
+#     outputDir: out/prod/
+`
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Create, check, and show gomplate config files",
+	}
+	cmd.AddCommand(newConfigInitCmd(), newConfigLintCmd(), newConfigPrintCmd())
+	return cmd
+}
+
+func newConfigInitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init [file]",
+		Short: "Write a commented example config file (" + defaultConfigFile + " by default, or - for standard output)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := defaultConfigFile
+			if len(args) > 0 {
+				name = args[0]
+			}
+			if name == "-" {
+				_, err := io.WriteString(cmd.OutOrStdout(), configTemplate)
+				return err
+			}
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return err
+			}
+			if _, err := fs.Stat(name); err == nil && !force {
+				return fmt.Errorf("config file %s already exists (use --force to overwrite it)", name)
+			}
+			err = afero.WriteFile(fs, name, []byte(configTemplate), 0644)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "wrote %s\n", name)
+			return nil
+		},
+	}
+	cmd.Flags().Bool("force", false, "overwrite an existing file")
+	return cmd
+}
+
+func newConfigLintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check the config file for unknown keys and invalid settings",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return lintConfig(cmd)
+		},
+	}
+	cmd.Flags().String("config", defaultConfigFile, "config file to check")
+	cmd.Flags().String("profile", "", "`name` of the config file profile to check [$GOMPLATE_PROFILE]")
+	cmd.Flags().StringSliceP("datasource-header", "H", nil, "HTTP `header` field in 'alias=Name: value' form, for remote config files")
+	return cmd
+}
+
+// lintConfig - parse the config file strictly and validate it, printing
+// each problem found
+func lintConfig(cmd *cobra.Command) error {
+	out := cmd.OutOrStdout()
+	cfgFile, _ := pickConfigFile(cmd)
+
+	// the file is required, even when the default is used
+	if !cmd.Flags().Changed("config") {
+		if err := cmd.Flags().Set("config", cfgFile); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := readConfigFileStrict(cmd, true)
+	if err != nil {
+		var uerr *config.UnknownKeysError
+		if errors.As(err, &uerr) {
+			for _, k := range uerr.Keys {
+				fmt.Fprintf(out, "%s: %s\n", cfgFile, k)
+			}
+			return fmt.Errorf("%s: found %d unknown key(s)", cfgFile, len(uerr.Keys))
+		}
+		return err
+	}
+
+	// validate a copy with defaults applied, the same way as when rendering
+	c := *cfg
+	c.ApplyDefaults()
+	if err := c.Validate(); err != nil {
+		fmt.Fprintf(out, "%s: %v\n", cfgFile, err)
+		return fmt.Errorf("%s: invalid config", cfgFile)
+	}
+
+	fmt.Fprintf(out, "%s: ok\n", cfgFile)
+	return nil
+}
+
+func newConfigPrintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "print",
+		Short: "Print the final config, after merging the config file, flags, environment variables, and defaults, and where each setting came from",
+		Args:  optionalExecArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cfg, prov, err := loadConfigWithProvenance(cmd, args)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(cmd.OutOrStdout(), cfg.StringWithProvenance(prov))
+			return err
+		},
+	}
+	// accept the same flags as the main command, so their effect can be seen
+	initFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigInitCmd(t *testing.T) {
+	fs = afero.NewMemMapFs()
+	defer func() { fs = afero.NewOsFs() }()
+
+	cmd := newConfigInitCmd()
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs(nil)
+	cmd.SetErr(&bytes.Buffer{})
+	assert.NoError(t, cmd.Execute())
+
+	b, err := afero.ReadFile(fs, defaultConfigFile)
+	assert.NoError(t, err)
+	assert.Equal(t, configTemplate, string(b))
+
+	// won't overwrite without --force
+	cmd.SetArgs(nil)
+	assert.Error(t, cmd.Execute())
+	cmd.SetArgs([]string{"--force"})
+	assert.NoError(t, cmd.Execute())
+
+	out := &bytes.Buffer{}
+	cmd = newConfigInitCmd()
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"-"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, configTemplate, out.String())
+}
+
+func TestConfigLintCmd(t *testing.T) {
+	fs = afero.NewMemMapFs()
+	defer func() { fs = afero.NewOsFs() }()
+
+	lint := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		cmd := newConfigLintCmd()
+		cmd.SilenceErrors = true
+		cmd.SetOut(out)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	// no config file
+	_, err := lint()
+	assert.Error(t, err)
+
+	// the template is valid
+	afero.WriteFile(fs, defaultConfigFile, []byte(configTemplate), 0644)
+	out, err := lint()
+	assert.NoError(t, err)
+	assert.Equal(t, ".gomplate.yaml: ok\n", out)
+
+	afero.WriteFile(fs, "typos.yaml", []byte("inputdir: in/\noutputDir: out/\nfoo: bar\n"), 0644)
+	out, err = lint("--config", "typos.yaml")
+	assert.EqualError(t, err, "typos.yaml: found 2 unknown key(s)")
+	assert.Equal(t, `typos.yaml: line 1: unknown key "inputdir" (did you mean "inputDir"?)
+typos.yaml: line 3: unknown key "foo"
+`, out)
+
+	afero.WriteFile(fs, "invalid.yaml", []byte("outputDir: out/\n"), 0644)
+	out, err = lint("--config", "invalid.yaml")
+	assert.EqualError(t, err, "invalid.yaml: invalid config")
+	assert.Equal(t, "invalid.yaml: these options must be set together: 'outputDir', 'inputDir'\n", out)
+}
+
+func TestConfigPrintCmd(t *testing.T) {
+	fs = afero.NewMemMapFs()
+	defer func() { fs = afero.NewOsFs() }()
+
+	afero.WriteFile(fs, defaultConfigFile, []byte("in: hello\nleftDelim: ((\n"), 0644)
+
+	out := &bytes.Buffer{}
+	cmd := newConfigPrintCmd()
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--right-delim", "))"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, `---
+in: hello # from config file .gomplate.yaml
+outputFiles: ['-'] # from default
+leftDelim: (( # from config file .gomplate.yaml
+rightDelim: )) # from flag
+pluginTimeout: 5s # from default
+`, out.String())
+}
@@ -7,9 +7,13 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"os/signal"
+	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/hairyhenderson/gomplate/v3"
 	"github.com/hairyhenderson/gomplate/v3/env"
@@ -28,10 +32,7 @@ func postRunExec(ctx context.Context, cfg *config.Config) error {
 		log := zerolog.Ctx(ctx)
 		log.Debug().Strs("args", args).Msg("running post-exec command")
 
-		name := args[0]
-		args = args[1:]
-		// nolint: gosec
-		c := exec.CommandContext(ctx, name, args...)
+		c := postExecCommand(ctx, cfg)
 		c.Stdin = cfg.PostExecInput
 		c.Stderr = os.Stderr
 		c.Stdout = os.Stdout
@@ -53,6 +54,30 @@ func postRunExec(ctx context.Context, cfg *config.Config) error {
 	return nil
 }
 
+// postExecCommand - the post-exec command, which is run through the shell
+// when PostExecShell is set
+func postExecCommand(ctx context.Context, cfg *config.Config) *exec.Cmd {
+	name, args := cfg.PostExec[0], cfg.PostExec[1:]
+	if cfg.PostExecShell {
+		name, args = "sh", []string{"-c", strings.Join(cfg.PostExec, " ")}
+		if runtime.GOOS == "windows" {
+			name, args = "cmd", []string{"/C", strings.Join(cfg.PostExec, " ")}
+		}
+	}
+	// nolint: gosec
+	c := exec.CommandContext(ctx, name, args...)
+	c.Dir = cfg.PostExecDir
+	if len(cfg.PostExecEnv) > 0 {
+		env := make([]string, 0, len(cfg.PostExecEnv))
+		for k, v := range cfg.PostExecEnv {
+			env = append(env, k+"="+v)
+		}
+		sort.Strings(env)
+		c.Env = append(os.Environ(), env...)
+	}
+	return c
+}
+
 // optionalExecArgs - implements cobra.PositionalArgs. Allows extra args following
 // a '--', but not otherwise.
 func optionalExecArgs(cmd *cobra.Command, args []string) error {
@@ -74,16 +99,21 @@ func newGomplateCmd() *cobra.Command {
 			ctx := cmd.Context()
 			log := zerolog.Ctx(ctx)
 
-			cfg, err := loadConfig(cmd, args)
+			cfg, prov, err := loadConfigWithProvenance(cmd, args)
 			if err != nil {
 				return err
 			}
 
+			if p, _ := cmd.Flags().GetBool("print-config"); p {
+				_, err = io.WriteString(cmd.OutOrStdout(), cfg.StringWithProvenance(prov))
+				return err
+			}
+
 			log.Debug().Msgf("starting %s", cmd.Name())
 			log.Debug().
 				Str("version", version.Version).
 				Str("build", version.GitCommit).
-				Msgf("config is:\n%v", cfg)
+				Msgf("config is:\n%v", cfg.StringWithProvenance(prov))
 
 			err = gomplate.RunTemplatesWithContext(ctx, cfg)
 			cmd.SilenceErrors = true
@@ -113,7 +143,13 @@ func initFlags(command *cobra.Command) {
 
 	command.Flags().StringSliceP("context", "c", nil, "pre-load a `datasource` into the context, in alias=URL form. Use the special alias `.` to set the root context.")
 
+	command.Flags().StringSlice("values", nil, "values `file` (or datasource URL) to deep-merge into the context. Can be specified multiple times - later files take precedence")
+	command.Flags().StringArray("set", nil, "set a context `value` in key.path=value form, overriding datasources. Separate multiple values with commas, or specify multiple times")
+	command.Flags().StringArray("set-file", nil, "set a context value from a file's contents, in key.path=`file` form. Can be specified multiple times")
+
 	command.Flags().StringSlice("plugin", nil, "plug in an external command as a function in name=path form. Can be specified multiple times")
+	command.Flags().Bool("enable-exec", false, "allow templates to run arbitrary commands with the exec.Run function")
+	command.Flags().Bool("enable-sprig", false, "add the Sprig functions (except those whose names collide with gomplate's functions)")
 
 	command.Flags().StringSliceP("file", "f", []string{"-"}, "Template `file` to process. Omit to use standard input, or use --in or --input-dir")
 	command.Flags().StringP("in", "i", "", "Template `string` to process (alternative to --file and --input-dir)")
@@ -128,7 +164,13 @@ func initFlags(command *cobra.Command) {
 	command.Flags().String("output-map", "", "Template `string` to map the input file to an output path")
 	command.Flags().String("chmod", "", "set the mode for output file(s). Omit to inherit from input file(s)")
 
+	command.Flags().String("missing-key", "", "`mode` for handling references to missing map keys: error (the default), zero, invalid, or default")
+	command.Flags().String("missing-key-default", "", "the `value` to print for missing map keys - implies --missing-key=default")
+
 	command.Flags().Bool("exec-pipe", false, "pipe the output to the post-run exec command")
+	command.Flags().String("exec-dir", "", "working `directory` for the post-run exec command")
+	command.Flags().StringSlice("exec-env", nil, "environment variable for the post-run exec command, in `name=value` form. Can be specified multiple times")
+	command.Flags().Bool("exec-shell", false, "run the post-run exec command with the shell (sh -c, or cmd /C on Windows)")
 
 	ldDefault := env.Getenv("GOMPLATE_LEFT_DELIM", "{{")
 	rdDefault := env.Getenv("GOMPLATE_RIGHT_DELIM", "}}")
@@ -138,6 +180,9 @@ func initFlags(command *cobra.Command) {
 	command.Flags().BoolP("verbose", "V", false, "output extra information about what gomplate is doing")
 
 	command.Flags().String("config", defaultConfigFile, "config file (overridden by commandline flags)")
+	command.Flags().Bool("strict-config", false, "fail on unknown keys in the config file")
+	command.Flags().String("profile", "", "`name` of the config file profile to use [$GOMPLATE_PROFILE]")
+	command.Flags().Bool("print-config", false, "print the final config, with where each setting came from, instead of rendering")
 }
 
 func main() {
@@ -147,6 +192,7 @@ func main() {
 
 	command := newGomplateCmd()
 	initFlags(command)
+	command.AddCommand(newConfigCmd())
 	if err := command.ExecuteContext(ctx); err != nil {
 		log := zerolog.Ctx(ctx)
 		log.Fatal().Err(err).Send()
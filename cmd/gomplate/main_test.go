@@ -1 +1,37 @@
 package main
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v3/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostExecCommand(t *testing.T) {
+	ctx := context.Background()
+	c := postExecCommand(ctx, &config.Config{PostExec: []string{"echo", "foo"}})
+	assert.Equal(t, []string{"echo", "foo"}, c.Args)
+	assert.Empty(t, c.Dir)
+	assert.Nil(t, c.Env)
+
+	c = postExecCommand(ctx, &config.Config{
+		PostExec:    []string{"echo", "foo"},
+		PostExecDir: "/tmp",
+		PostExecEnv: map[string]string{"FOO": "bar", "BAZ": "qux"},
+	})
+	assert.Equal(t, "/tmp", c.Dir)
+	assert.Equal(t, append(os.Environ(), "BAZ=qux", "FOO=bar"), c.Env)
+
+	c = postExecCommand(ctx, &config.Config{
+		PostExec:      []string{"make build &&", "./deploy.sh"},
+		PostExecShell: true,
+	})
+	if runtime.GOOS == "windows" {
+		assert.Equal(t, []string{"cmd", "/C", "make build && ./deploy.sh"}, c.Args)
+	} else {
+		assert.Equal(t, []string{"sh", "-c", "make build && ./deploy.sh"}, c.Args)
+	}
+}
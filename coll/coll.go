@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hairyhenderson/gomplate/v3/conv"
 	iconv "github.com/hairyhenderson/gomplate/v3/internal/conv"
@@ -205,6 +207,21 @@ func mergeValues(d map[string]interface{}, o map[string]interface{}) map[string]
 //
 // Does not modify the input list.
 func Sort(key string, list interface{}) (out []interface{}, err error) {
+	return SortBy(list, key)
+}
+
+// SortBy sorts a given array or slice by zero or more keys. Each key is a
+// dotted path into the list elements (maps, structs, or slices), optionally
+// prefixed with '-' for descending order or '+' for ascending order, and
+// optionally suffixed with ':natural' or ':numeric' to change how strings are
+// compared. An empty path refers to the element itself, so ":natural" sorts a
+// list of strings in natural order.
+//
+// Later keys are only consulted when elements compare equal on all earlier
+// keys.
+//
+// Does not modify the input list.
+func SortBy(list interface{}, keys ...string) (out []interface{}, err error) {
 	if list == nil {
 		return nil, nil
 	}
@@ -213,56 +230,302 @@ func Sort(key string, list interface{}) (out []interface{}, err error) {
 	if err != nil {
 		return nil, err
 	}
+
+	specs := make([]sortKey, len(keys))
+	for i, k := range keys {
+		specs[i], err = parseSortKey(k)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(specs) == 0 {
+		specs = []sortKey{{}}
+	}
+
 	// if the types are all the same, we can sort the slice
 	if sameTypes(ia) {
 		s := make([]interface{}, len(ia))
 		// make a copy so the original is unmodified
 		copy(s, ia)
 		sort.SliceStable(s, func(i, j int) bool {
-			return lessThan(key)(s[i], s[j])
+			for _, k := range specs {
+				if c := k.compare(s[i], s[j]); c != 0 {
+					return c < 0
+				}
+			}
+			return false
 		})
 		return s, nil
 	}
 	return ia, nil
 }
 
-// lessThan - compare two values of the same type
-func lessThan(key string) func(left, right interface{}) bool {
-	return func(left, right interface{}) bool {
-		val := reflect.Indirect(reflect.ValueOf(left))
-		rval := reflect.Indirect(reflect.ValueOf(right))
-		switch val.Kind() {
-		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
-			return val.Int() < rval.Int()
-		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64:
-			return val.Uint() < rval.Uint()
-		case reflect.Float32, reflect.Float64:
-			return val.Float() < rval.Float()
-		case reflect.String:
-			return val.String() < rval.String()
-		case reflect.MapOf(
-			reflect.TypeOf(reflect.String),
-			reflect.TypeOf(reflect.Interface),
-		).Kind():
-			kval := reflect.ValueOf(key)
-			if !val.MapIndex(kval).IsValid() {
-				return false
+// string comparison modes for sort keys
+const (
+	lexicalOrder = iota
+	naturalOrder
+	numericOrder
+)
+
+// sortKey - a parsed sort key
+type sortKey struct {
+	path  []string
+	desc  bool
+	order int
+}
+
+func parseSortKey(key string) (sortKey, error) {
+	k := sortKey{}
+	switch {
+	case strings.HasPrefix(key, "-"):
+		k.desc = true
+		key = key[1:]
+	case strings.HasPrefix(key, "+"):
+		key = key[1:]
+	}
+
+	if i := strings.LastIndex(key, ":"); i >= 0 {
+		switch key[i+1:] {
+		case "natural":
+			k.order = naturalOrder
+		case "numeric":
+			k.order = numericOrder
+		case "lexical":
+			k.order = lexicalOrder
+		default:
+			return k, fmt.Errorf("invalid sort key %q: unknown order %q (must be one of 'lexical', 'natural', 'numeric')", key, key[i+1:])
+		}
+		key = key[:i]
+	}
+
+	if key != "" {
+		k.path = strings.Split(key, ".")
+	}
+	return k, nil
+}
+
+// compare - compare the values found at the key's path in left and right.
+// Returns -1, 0, or 1.
+func (k sortKey) compare(left, right interface{}) int {
+	lval, lok := lookupPath(reflect.ValueOf(left), k.path)
+	rval, rok := lookupPath(reflect.ValueOf(right), k.path)
+
+	var c int
+	switch {
+	case !lok && !rok:
+		return 0
+	// missing values sort before present values
+	case !lok:
+		c = -1
+	case !rok:
+		c = 1
+	default:
+		c = compareValues(lval, rval, k.order)
+	}
+
+	if k.desc {
+		return -c
+	}
+	return c
+}
+
+// lookupPath - find the value at the given path, descending into maps,
+// structs, slices, and arrays. Returns false if the path can't be resolved.
+func lookupPath(v reflect.Value, path []string) (reflect.Value, bool) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return v, false
+	}
+	if len(path) == 0 {
+		return v, true
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return v, false
+		}
+		// support keys containing dots, for compatibility
+		if e := v.MapIndex(reflect.ValueOf(strings.Join(path, ".")).Convert(v.Type().Key())); e.IsValid() {
+			return lookupPath(e, nil)
+		}
+		e := v.MapIndex(reflect.ValueOf(path[0]).Convert(v.Type().Key()))
+		if !e.IsValid() {
+			return e, false
+		}
+		return lookupPath(e, path[1:])
+	case reflect.Struct:
+		f := v.FieldByName(path[0])
+		if !f.IsValid() || !f.CanInterface() {
+			return f, false
+		}
+		return lookupPath(f, path[1:])
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(path[0])
+		if err != nil || i < 0 || i >= v.Len() {
+			return v, false
+		}
+		return lookupPath(v.Index(i), path[1:])
+	default:
+		return v, false
+	}
+}
+
+// indirect - dereference pointers and interfaces until a concrete value is found
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// compareValues - compare two values. Numbers of any kind can be compared with
+// each other, strings are compared according to the given order. Values that
+// aren't comparable are considered equal.
+func compareValues(left, right reflect.Value, order int) int {
+	if lf, ok := toFloat(left); ok {
+		if rf, ok := toFloat(right); ok {
+			return compareNumbers(left, right, lf, rf)
+		}
+	}
+
+	switch {
+	case left.Kind() == reflect.String && right.Kind() == reflect.String:
+		return compareStrings(left.String(), right.String(), order)
+	case left.Kind() == reflect.Bool && right.Kind() == reflect.Bool:
+		l, r := left.Bool(), right.Bool()
+		switch {
+		case l == r:
+			return 0
+		case !l:
+			return -1
+		default:
+			return 1
+		}
+	default:
+		// it's not really comparable, so...
+		return 0
+	}
+}
+
+func compareNumbers(left, right reflect.Value, lf, rf float64) int {
+	// compare integers exactly where possible, to avoid precision loss
+	if isInt(left) && isInt(right) {
+		l, r := left.Int(), right.Int()
+		switch {
+		case l < r:
+			return -1
+		case l > r:
+			return 1
+		default:
+			return 0
+		}
+	}
+	switch {
+	case lf < rf:
+		return -1
+	case lf > rf:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		return true
+	default:
+		return false
+	}
+}
+
+func toFloat(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		return float64(v.Int()), true
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func compareStrings(left, right string, order int) int {
+	switch order {
+	case naturalOrder:
+		return naturalCompare(left, right)
+	case numericOrder:
+		lf, lerr := strconv.ParseFloat(strings.TrimSpace(left), 64)
+		rf, rerr := strconv.ParseFloat(strings.TrimSpace(right), 64)
+		switch {
+		case lerr == nil && rerr == nil:
+			return compareNumbers(reflect.ValueOf(lf), reflect.ValueOf(rf), lf, rf)
+		// non-numeric strings sort after numeric ones
+		case lerr == nil:
+			return -1
+		case rerr == nil:
+			return 1
+		}
+	}
+	return strings.Compare(left, right)
+}
+
+// naturalCompare - compare strings such that runs of digits are compared by
+// their numeric value, so that "file2" sorts before "file10".
+func naturalCompare(left, right string) int {
+	for left != "" && right != "" {
+		lchunk, lnum := nextChunk(left)
+		rchunk, rnum := nextChunk(right)
+		left, right = left[len(lchunk):], right[len(rchunk):]
+
+		if lnum && rnum {
+			l := strings.TrimLeft(lchunk, "0")
+			r := strings.TrimLeft(rchunk, "0")
+			if len(l) != len(r) {
+				if len(l) < len(r) {
+					return -1
+				}
+				return 1
 			}
-			newleft := val.MapIndex(kval).Interface()
-			newright := rval.MapIndex(kval).Interface()
-			return lessThan("")(newleft, newright)
-		case reflect.Struct:
-			if !val.FieldByName(key).IsValid() {
-				return false
+			if c := strings.Compare(l, r); c != 0 {
+				return c
 			}
-			newleft := val.FieldByName(key).Interface()
-			newright := rval.FieldByName(key).Interface()
-			return lessThan("")(newleft, newright)
-		default:
-			// it's not really comparable, so...
-			return false
+			// equal values - fewer leading zeroes sorts first
+			if c := len(lchunk) - len(rchunk); c != 0 {
+				if c < 0 {
+					return -1
+				}
+				return 1
+			}
+			continue
 		}
+
+		if c := strings.Compare(lchunk, rchunk); c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(left, right)
+}
+
+// nextChunk - returns the leading run of either digits or non-digits in s,
+// and whether it's numeric
+func nextChunk(s string) (string, bool) {
+	num := isDigit(s[0])
+	i := 1
+	for i < len(s) && isDigit(s[i]) == num {
+		i++
 	}
+	return s[:i], num
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
 }
 
 func sameTypes(a []interface{}) bool {
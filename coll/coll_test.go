@@ -332,7 +332,9 @@ func TestLessThan(t *testing.T) {
 
 	for _, d := range data {
 		t.Run(fmt.Sprintf(`LessThan("%s")(<%T>%#v,%#v)==%v`, d.key, d.left, d.left, d.right, d.out), func(t *testing.T) {
-			assert.Equal(t, d.out, lessThan(d.key)(d.left, d.right))
+			k, err := parseSortKey(d.key)
+			assert.NoError(t, err)
+			assert.Equal(t, d.out, k.compare(d.left, d.right) < 0)
 		})
 	}
 }
@@ -457,6 +459,110 @@ func TestSort(t *testing.T) {
 	}
 }
 
+func TestSortBy(t *testing.T) {
+	_, err := SortBy([]string{"a"}, "a:bogus")
+	assert.Error(t, err)
+
+	people := []interface{}{
+		map[string]interface{}{"name": "Bart", "age": 10, "address": map[string]interface{}{"street": "Evergreen Terrace", "number": 742}},
+		map[string]interface{}{"name": "Ned", "age": 60, "address": map[string]interface{}{"street": "Evergreen Terrace", "number": 744}},
+		map[string]interface{}{"name": "Lisa", "age": 8, "address": map[string]interface{}{"street": "Evergreen Terrace", "number": 742}},
+		map[string]interface{}{"name": "Moe", "age": 50},
+	}
+	names := func(l []interface{}) []string {
+		out := make([]string, len(l))
+		for i, v := range l {
+			out[i] = v.(map[string]interface{})["name"].(string)
+		}
+		return out
+	}
+
+	data := []struct {
+		keys []string
+		out  []string
+	}{
+		{[]string{"age"}, []string{"Lisa", "Bart", "Moe", "Ned"}},
+		{[]string{"-age"}, []string{"Ned", "Moe", "Bart", "Lisa"}},
+		{[]string{"+name"}, []string{"Bart", "Lisa", "Moe", "Ned"}},
+		{[]string{"address.number", "name"}, []string{"Moe", "Bart", "Lisa", "Ned"}},
+		{[]string{"-address.number", "-name"}, []string{"Ned", "Lisa", "Bart", "Moe"}},
+		{[]string{"address.street", "-age"}, []string{"Moe", "Ned", "Bart", "Lisa"}},
+	}
+	for _, d := range data {
+		t.Run(fmt.Sprintf("%v", d.keys), func(t *testing.T) {
+			out, err := SortBy(people, d.keys...)
+			assert.NoError(t, err)
+			assert.Equal(t, d.out, names(out))
+		})
+	}
+
+	files := []string{"file10", "file2", "File1", "file01", "file1"}
+	out, err := SortBy(files)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{"File1", "file01", "file1", "file10", "file2"}, out)
+
+	out, err = SortBy(files, ":natural")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{"File1", "file1", "file01", "file2", "file10"}, out)
+
+	out, err = SortBy(files, "-:natural")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{"file10", "file2", "file01", "file1", "File1"}, out)
+
+	out, err = SortBy([]string{"10", "9", "1.5", "foo", "-2"}, ":numeric")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{"-2", "1.5", "9", "10", "foo"}, out)
+
+	out, err = SortBy([]interface{}{
+		map[string]interface{}{"v": 2.5},
+		map[string]interface{}{"v": 1},
+		map[string]interface{}{"v": uint(2)},
+	}, "v")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{
+		map[string]interface{}{"v": 1},
+		map[string]interface{}{"v": uint(2)},
+		map[string]interface{}{"v": 2.5},
+	}, out)
+
+	out, err = SortBy([]interface{}{
+		map[string]interface{}{"l": []interface{}{"b", "z"}},
+		map[string]interface{}{"l": []interface{}{"a", "y"}},
+	}, "l.1")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{
+		map[string]interface{}{"l": []interface{}{"a", "y"}},
+		map[string]interface{}{"l": []interface{}{"b", "z"}},
+	}, out)
+
+	type inner struct{ Y int }
+	type outer struct{ In *inner }
+	out, err = SortBy([]outer{{&inner{3}}, {nil}, {&inner{1}}}, "In.Y")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{outer{nil}, outer{&inner{1}}, outer{&inner{3}}}, out)
+}
+
+func TestNaturalCompare(t *testing.T) {
+	data := []struct {
+		l, r string
+		c    int
+	}{
+		{"", "", 0},
+		{"a", "", 1},
+		{"a1", "a1", 0},
+		{"a2", "a10", -1},
+		{"a10", "a2", 1},
+		{"a02", "a2", 1},
+		{"1.2.10", "1.2.9", 1},
+		{"v1.10.0", "v1.9.3", 1},
+		{"abc", "abd", -1},
+		{"x9y", "x9z", -1},
+	}
+	for _, d := range data {
+		assert.Equal(t, d.c, naturalCompare(d.l, d.r), "%q vs %q", d.l, d.r)
+	}
+}
+
 func TestFlatten(t *testing.T) {
 	data := []struct {
 		depth    int
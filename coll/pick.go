@@ -0,0 +1,242 @@
+package coll
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	iconv "github.com/hairyhenderson/gomplate/v3/internal/conv"
+)
+
+// PickPath returns a copy of the input containing only the values found at
+// the given paths.
+//
+// Paths are dot-separated map keys, with list elements referenced as "[n]".
+// The wildcards "*" (any map key) and "[*]" (any list element) are supported,
+// so "spec.containers[*].env" selects the env of every container.
+//
+// Lists in the output only contain the elements that matched, in their
+// original order.
+func PickPath(in interface{}, paths ...string) (interface{}, error) {
+	root, err := newPathTree(paths)
+	if err != nil {
+		return nil, err
+	}
+	out, _ := root.pick(in)
+	return out, nil
+}
+
+// OmitPath returns a copy of the input with the values found at the given
+// paths removed. See PickPath for the path syntax.
+//
+// The input is not modified.
+func OmitPath(in interface{}, paths ...string) (interface{}, error) {
+	root, err := newPathTree(paths)
+	if err != nil {
+		return nil, err
+	}
+	return root.omit(in), nil
+}
+
+const (
+	anyKey   = "*"
+	anyIndex = "[*]"
+)
+
+// pathTree - a set of parsed paths, sharing common prefixes
+type pathTree struct {
+	// true if a path ends at this node
+	leaf     bool
+	children map[string]*pathTree
+}
+
+func newPathTree(paths []string) (*pathTree, error) {
+	root := &pathTree{}
+	for _, p := range paths {
+		segs, err := parseFieldPath(p)
+		if err != nil {
+			return nil, err
+		}
+		n := root
+		for _, s := range segs {
+			if n.children == nil {
+				n.children = map[string]*pathTree{}
+			}
+			c, ok := n.children[s]
+			if !ok {
+				c = &pathTree{}
+				n.children[s] = c
+			}
+			n = c
+		}
+		n.leaf = true
+	}
+	return root, nil
+}
+
+// parseFieldPath - split a path like "a.b[0].c[*]" into segments. Map keys are
+// returned as-is, and list indexes are returned in brackets ("[0]", "[*]").
+func parseFieldPath(p string) ([]string, error) {
+	if p == "" {
+		return nil, fmt.Errorf("path must not be empty")
+	}
+	segs := []string{}
+	for _, part := range strings.Split(p, ".") {
+		key := part
+		idx := ""
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			key, idx = part[:i], part[i:]
+		}
+		if key == "" && (idx == "" || len(segs) > 0) {
+			return nil, fmt.Errorf("invalid path %q: empty key", p)
+		}
+		if key != "" {
+			segs = append(segs, key)
+		}
+		for idx != "" {
+			end := strings.IndexByte(idx, ']')
+			if idx[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("invalid path %q: malformed index %q", p, idx)
+			}
+			i := idx[1:end]
+			if i != "*" {
+				if n, err := strconv.Atoi(i); err != nil || n < 0 {
+					return nil, fmt.Errorf("invalid path %q: index must be a non-negative integer or '*', got %q", p, i)
+				}
+			}
+			segs = append(segs, idx[:end+1])
+			idx = idx[end+1:]
+		}
+	}
+	return segs, nil
+}
+
+// mergePathTrees - combine the given nodes into one, so that a value can be matched
+// against several overlapping paths (e.g. "a.*" and "a.b") at once
+func mergePathTrees(nodes []*pathTree) *pathTree {
+	if len(nodes) == 1 {
+		return nodes[0]
+	}
+	out := &pathTree{children: map[string]*pathTree{}}
+	grouped := map[string][]*pathTree{}
+	for _, n := range nodes {
+		out.leaf = out.leaf || n.leaf
+		for k, c := range n.children {
+			grouped[k] = append(grouped[k], c)
+		}
+	}
+	for k, g := range grouped {
+		out.children[k] = mergePathTrees(g)
+	}
+	return out
+}
+
+// keyMatches - the child nodes matching the given map key
+func (t *pathTree) keyMatches(key string) []*pathTree {
+	out := []*pathTree{}
+	if c, ok := t.children[key]; ok {
+		out = append(out, c)
+	}
+	if c, ok := t.children[anyKey]; ok {
+		out = append(out, c)
+	}
+	return out
+}
+
+// indexMatches - the child nodes matching the given list index
+func (t *pathTree) indexMatches(i int) []*pathTree {
+	out := []*pathTree{}
+	if c, ok := t.children["["+strconv.Itoa(i)+"]"]; ok {
+		out = append(out, c)
+	}
+	if c, ok := t.children[anyIndex]; ok {
+		out = append(out, c)
+	}
+	return out
+}
+
+func (t *pathTree) pick(in interface{}) (interface{}, bool) {
+	if t.leaf {
+		return in, true
+	}
+	if m, ok := toStringMap(in); ok {
+		out := map[string]interface{}{}
+		for k, v := range m {
+			nodes := t.keyMatches(k)
+			if len(nodes) == 0 {
+				continue
+			}
+			if o, ok := mergePathTrees(nodes).pick(v); ok {
+				out[k] = o
+			}
+		}
+		return out, len(out) > 0
+	}
+	if l, err := iconv.InterfaceSlice(in); err == nil {
+		out := []interface{}{}
+		for i, v := range l {
+			nodes := t.indexMatches(i)
+			if len(nodes) == 0 {
+				continue
+			}
+			if o, ok := mergePathTrees(nodes).pick(v); ok {
+				out = append(out, o)
+			}
+		}
+		return out, len(out) > 0
+	}
+	return nil, false
+}
+
+func (t *pathTree) omit(in interface{}) interface{} {
+	if m, ok := toStringMap(in); ok {
+		out := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			nodes := t.keyMatches(k)
+			if len(nodes) == 0 {
+				out[k] = v
+				continue
+			}
+			n := mergePathTrees(nodes)
+			if n.leaf {
+				continue
+			}
+			out[k] = n.omit(v)
+		}
+		return out
+	}
+	if l, err := iconv.InterfaceSlice(in); err == nil {
+		out := make([]interface{}, 0, len(l))
+		for i, v := range l {
+			nodes := t.indexMatches(i)
+			if len(nodes) == 0 {
+				out = append(out, v)
+				continue
+			}
+			n := mergePathTrees(nodes)
+			if n.leaf {
+				continue
+			}
+			out = append(out, n.omit(v))
+		}
+		return out
+	}
+	return in
+}
+
+// toStringMap - convert any map with string keys to a map[string]interface{}
+func toStringMap(in interface{}) (map[string]interface{}, bool) {
+	if m, ok := in.(map[string]interface{}); ok {
+		return m, true
+	}
+	v := reflect.ValueOf(in)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	m := make(map[string]interface{}, v.Len())
+	for _, k := range v.MapKeys() {
+		m[k.String()] = v.MapIndex(k).Interface()
+	}
+	return m, true
+}
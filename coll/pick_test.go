@@ -0,0 +1,151 @@
+package coll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPod() map[string]interface{} {
+	return map[string]interface{}{
+		"kind": "Pod",
+		"metadata": map[string]interface{}{
+			"name":   "web",
+			"labels": map[string]interface{}{"app": "web", "tier": "frontend"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name":  "nginx",
+					"image": "nginx:1.19",
+					"env":   []interface{}{map[string]interface{}{"name": "FOO", "value": "bar"}},
+				},
+				map[string]interface{}{
+					"name":  "sidecar",
+					"image": "busybox",
+				},
+			},
+		},
+	}
+}
+
+func TestParseFieldPath(t *testing.T) {
+	data := []struct {
+		in  string
+		out []string
+	}{
+		{"a", []string{"a"}},
+		{"a.b.c", []string{"a", "b", "c"}},
+		{"a[0]", []string{"a", "[0]"}},
+		{"a[*].b", []string{"a", "[*]", "b"}},
+		{"[*].b", []string{"[*]", "b"}},
+		{"a[1][2]", []string{"a", "[1]", "[2]"}},
+		{"*.b", []string{"*", "b"}},
+	}
+	for _, d := range data {
+		out, err := parseFieldPath(d.in)
+		assert.NoError(t, err)
+		assert.Equal(t, d.out, out, d.in)
+	}
+
+	for _, in := range []string{"", "a..b", "a.[0]", "a[", "a[x]", "a[-1]", "a[0]b"} {
+		_, err := parseFieldPath(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestPickPath(t *testing.T) {
+	_, err := PickPath(testPod(), "a[")
+	assert.Error(t, err)
+
+	out, err := PickPath(testPod(), "kind", "metadata.name")
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"kind":     "Pod",
+		"metadata": map[string]interface{}{"name": "web"},
+	}, out)
+
+	out, err = PickPath(testPod(), "spec.containers[*].env")
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{
+					"env": []interface{}{map[string]interface{}{"name": "FOO", "value": "bar"}},
+				},
+			},
+		},
+	}, out)
+
+	out, err = PickPath(testPod(), "spec.containers[*].name", "spec.containers[1].image")
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "nginx"},
+				map[string]interface{}{"name": "sidecar", "image": "busybox"},
+			},
+		},
+	}, out)
+
+	out, err = PickPath(testPod(), "metadata.*.app")
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "web"},
+		},
+	}, out)
+
+	out, err = PickPath([]map[string]int{{"a": 1, "b": 2}, {"a": 3}}, "[*].b")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{map[string]interface{}{"b": 2}}, out)
+
+	out, err = PickPath(testPod(), "nope.nothing")
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{}, out)
+
+	out, err = PickPath("hello", "a")
+	assert.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestOmitPath(t *testing.T) {
+	_, err := OmitPath(testPod(), "a[x]")
+	assert.Error(t, err)
+
+	in := testPod()
+	out, err := OmitPath(in, "spec", "metadata.labels.tier")
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"kind": "Pod",
+		"metadata": map[string]interface{}{
+			"name":   "web",
+			"labels": map[string]interface{}{"app": "web"},
+		},
+	}, out)
+	// input must not be modified
+	assert.EqualValues(t, testPod(), in)
+
+	out, err = OmitPath(testPod(), "spec.containers[*].env", "spec.containers[*].image", "metadata", "kind")
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "nginx"},
+				map[string]interface{}{"name": "sidecar"},
+			},
+		},
+	}, out)
+
+	out, err = OmitPath([]interface{}{"a", "b", "c"}, "[1]")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{"a", "c"}, out)
+
+	out, err = OmitPath(map[string]interface{}{"a": map[string]interface{}{"x": 1, "y": 2}, "b": map[string]interface{}{"x": 3}}, "*.x")
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"a": map[string]interface{}{"y": 2}, "b": map[string]interface{}{}}, out)
+
+	out, err = OmitPath(42, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, out)
+}
@@ -0,0 +1,103 @@
+// Package compress contains functions for compressing and decompressing data
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// Magic numbers at the start of compressed streams, for formats that have them
+var (
+	GzipMagic = []byte{0x1f, 0x8b}
+	ZstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Gzip - compress the input with gzip at the given level, from 1 (fastest) to
+// 9 (best compression). Use -1 for the default level.
+func Gzip(in []byte, level int) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w, err := gzip.NewWriterLevel(buf, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(in); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Gunzip - decompress gzip-compressed input
+func Gunzip(in []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(in))
+	if err != nil {
+		return nil, errors.Wrap(err, "gunzip failed")
+	}
+	// nolint: errcheck
+	defer r.Close()
+	out, err := ioutil.ReadAll(r)
+	return out, errors.Wrap(err, "gunzip failed")
+}
+
+// Zstd - compress the input with Zstandard at the given level, from 1
+// (fastest) to 22 (best compression). Use 0 for the default level.
+func Zstd(in []byte, level int) ([]byte, error) {
+	opts := []zstd.EOption{}
+	if level != 0 {
+		if level < 1 || level > 22 {
+			return nil, errors.Errorf("invalid zstd compression level %d: must be between 1 and 22", level)
+		}
+		opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	}
+	w, err := zstd.NewWriter(nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// nolint: errcheck
+	defer w.Close()
+	return w.EncodeAll(in, nil), nil
+}
+
+// Unzstd - decompress Zstandard-compressed input
+func Unzstd(in []byte) ([]byte, error) {
+	r, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	out, err := r.DecodeAll(in, nil)
+	return out, errors.Wrap(err, "unzstd failed")
+}
+
+// Brotli - compress the input with Brotli at the given level, from 0 (fastest)
+// to 11 (best compression). Use -1 for the default level.
+func Brotli(in []byte, level int) ([]byte, error) {
+	if level == -1 {
+		level = brotli.DefaultCompression
+	}
+	if level < brotli.BestSpeed || level > brotli.BestCompression {
+		return nil, errors.Errorf("invalid brotli compression level %d: must be between 0 and 11", level)
+	}
+	buf := &bytes.Buffer{}
+	w := brotli.NewWriterLevel(buf, level)
+	if _, err := w.Write(in); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unbrotli - decompress Brotli-compressed input
+func Unbrotli(in []byte) ([]byte, error) {
+	out, err := ioutil.ReadAll(brotli.NewReader(bytes.NewReader(in)))
+	return out, errors.Wrap(err, "unbrotli failed")
+}
@@ -0,0 +1,51 @@
+package compress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTrip(t *testing.T) {
+	in := []byte(strings.Repeat("hello, world! ", 100))
+
+	data := []struct {
+		name   string
+		c      func([]byte, int) ([]byte, error)
+		d      func([]byte) ([]byte, error)
+		levels []int
+		magic  []byte
+	}{
+		{"gzip", Gzip, Gunzip, []int{-1, 1, 9}, GzipMagic},
+		{"zstd", Zstd, Unzstd, []int{0, 1, 22}, ZstdMagic},
+		{"brotli", Brotli, Unbrotli, []int{-1, 0, 11}, nil},
+	}
+	for _, d := range data {
+		for _, l := range d.levels {
+			out, err := d.c(in, l)
+			assert.NoError(t, err, d.name)
+			assert.True(t, len(out) < len(in), d.name)
+			assert.True(t, bytes.HasPrefix(out, d.magic), d.name)
+
+			orig, err := d.d(out)
+			assert.NoError(t, err, d.name)
+			assert.Equal(t, in, orig, d.name)
+		}
+
+		_, err := d.d([]byte("not compressed at all"))
+		assert.Error(t, err, d.name)
+	}
+}
+
+func TestInvalidLevels(t *testing.T) {
+	_, err := Gzip(nil, 10)
+	assert.Error(t, err)
+	_, err = Zstd(nil, 23)
+	assert.Error(t, err)
+	_, err = Zstd(nil, -1)
+	assert.Error(t, err)
+	_, err = Brotli(nil, 12)
+	assert.Error(t, err)
+}
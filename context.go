@@ -2,11 +2,16 @@ package gomplate
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/hairyhenderson/gomplate/v3/coll"
 	"github.com/hairyhenderson/gomplate/v3/data"
 	"github.com/hairyhenderson/gomplate/v3/internal/config"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 )
 
 // context for templates
@@ -36,3 +41,177 @@ func createTmplContext(ctx context.Context, contexts config.DSources, d *data.Da
 	}
 	return tctx, nil
 }
+
+// applyValuesFiles - read each of the values files as a datasource, and
+// deep-merge them into the context. Later files take precedence.
+func applyValuesFiles(c interface{}, files []string, d *data.Data) (interface{}, error) {
+	if len(files) == 0 {
+		return c, nil
+	}
+	m, err := contextMap(c)
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range files {
+		alias := fmt.Sprintf("values[%d]", i)
+		if _, err := d.DefineDatasource(alias, f); err != nil {
+			return nil, errors.Wrapf(err, "invalid values file %q", f)
+		}
+		v, err := d.Datasource(alias)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read values file %q", f)
+		}
+		vm, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("values file %q must contain a map, not %T", f, v)
+		}
+		m, err = coll.Merge(vm, m)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := c.(*tmplctx); ok {
+		t := tmplctx(m)
+		return &t, nil
+	}
+	return m, nil
+}
+
+// contextMap - the context as a map, if it is one
+func contextMap(c interface{}) (map[string]interface{}, error) {
+	switch t := c.(type) {
+	case *tmplctx:
+		return *t, nil
+	case map[string]interface{}:
+		return t, nil
+	default:
+		return nil, errors.Errorf("can't set values in a context of type %T", c)
+	}
+}
+
+// applySetValues - deep-merge the --set and --set-file values into the
+// context, in that order
+func applySetValues(c interface{}, setValues, setFiles []string) (interface{}, error) {
+	if len(setValues) == 0 && len(setFiles) == 0 {
+		return c, nil
+	}
+	m, err := contextMap(c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, arg := range setValues {
+		for _, pair := range splitEscaped(arg, ',', true) {
+			k, v, err := splitSetArg(pair)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid --set value %q", arg)
+			}
+			setPath(m, k, setValue(v))
+		}
+	}
+	for _, arg := range setFiles {
+		k, f, err := splitSetArg(arg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid --set-file value %q", arg)
+		}
+		b, err := afero.ReadFile(fs, f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read --set-file %q", f)
+		}
+		setPath(m, k, string(b))
+	}
+	return c, nil
+}
+
+// splitSetArg - split a key.path=value pair into the key path and the value
+func splitSetArg(pair string) ([]string, string, error) {
+	kv := splitEscaped(pair, '=', false)
+	if len(kv) < 2 || kv[0] == "" {
+		return nil, "", errors.New("must be in key=value form")
+	}
+	keys := splitEscaped(kv[0], '.', false)
+	for i, k := range keys {
+		if k == "" {
+			return nil, "", errors.Errorf("empty key in %q", kv[0])
+		}
+		keys[i] = unescape(k)
+	}
+	return keys, strings.Join(kv[1:], "="), nil
+}
+
+// setPath - set the value at the key path, creating (or replacing non-map)
+// intermediate values as necessary
+func setPath(m map[string]interface{}, keys []string, v interface{}) {
+	for _, k := range keys[:len(keys)-1] {
+		next, ok := m[k].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			m[k] = next
+		}
+		m = next
+	}
+	m[keys[len(keys)-1]] = v
+}
+
+// setValue - convert a --set value to a list (when in {a,b} form), bool,
+// int64, or nil when possible, otherwise a string
+func setValue(s string) interface{} {
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		l := []interface{}{}
+		if inner := s[1 : len(s)-1]; inner != "" {
+			for _, v := range splitEscaped(inner, ',', false) {
+				l = append(l, setValue(v))
+			}
+		}
+		return l
+	}
+	s = unescape(s)
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	// numbers with leading zeros are left as strings
+	if s == "0" || (s != "" && s[0] != '0') {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i
+		}
+	}
+	return s
+}
+
+// splitEscaped - split s on unescaped separators, optionally ignoring those
+// within braces. Escapes are left in place.
+func splitEscaped(s string, sep byte, braces bool) []string {
+	parts := []string{}
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			i++
+		case braces && c == '{':
+			depth++
+		case braces && c == '}' && depth > 0:
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unescape - remove backslash escapes
+func unescape(s string) string {
+	b := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
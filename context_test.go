@@ -8,6 +8,7 @@ import (
 
 	"github.com/hairyhenderson/gomplate/v3/data"
 	"github.com/hairyhenderson/gomplate/v3/internal/config"
+	"github.com/spf13/afero"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -58,3 +59,103 @@ func TestCreateContext(t *testing.T) {
 	ds = c.(map[string]interface{})
 	assert.Equal(t, "baz", ds["bar"])
 }
+
+func TestApplySetValues(t *testing.T) {
+	c, err := applySetValues(&tmplctx{}, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &tmplctx{}, c)
+
+	tctx := &tmplctx{
+		"config": map[string]interface{}{
+			"port": 80,
+			"host": "example.com",
+		},
+		"list": []interface{}{"a"},
+	}
+	c, err = applySetValues(tctx, []string{
+		"config.port=8080,config.tls=true",
+		"list.first=x",
+		`name=a\,b,dotted\.key=c`,
+		"tags={a,1,false},empty={}",
+		"none=null,zip=01234,eq=a=b",
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &tmplctx{
+		"config": map[string]interface{}{
+			"port": int64(8080),
+			"host": "example.com",
+			"tls":  true,
+		},
+		"list":       map[string]interface{}{"first": "x"},
+		"name":       "a,b",
+		"dotted.key": "c",
+		"tags":       []interface{}{"a", int64(1), false},
+		"empty":      []interface{}{},
+		"none":       nil,
+		"zip":        "01234",
+		"eq":         "a=b",
+	}, c)
+
+	fs = afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, "cert.pem", []byte("-----BEGIN CERTIFICATE-----\n"), 0600)
+	c, err = applySetValues(map[string]interface{}{"foo": "bar"},
+		[]string{"tls.cert=overridden"},
+		[]string{"tls.cert=cert.pem"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"foo": "bar",
+		"tls": map[string]interface{}{"cert": "-----BEGIN CERTIFICATE-----\n"},
+	}, c)
+
+	_, err = applySetValues(&tmplctx{}, nil, []string{"a=missing.txt"})
+	assert.Error(t, err)
+
+	_, err = applySetValues([]interface{}{}, []string{"a=b"}, nil)
+	assert.Error(t, err)
+
+	for _, bad := range []string{"a", "=b", "a..b=c", "a=b,c"} {
+		_, err = applySetValues(&tmplctx{}, []string{bad}, nil)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestApplyValuesFiles(t *testing.T) {
+	c, err := applyValuesFiles(&tmplctx{}, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &tmplctx{}, c)
+
+	os.Setenv("VALUES_A", "config: {port: 80, host: example.com}\nname: a")
+	defer os.Unsetenv("VALUES_A")
+	os.Setenv("VALUES_B", `{"config": {"port": 8080}, "extra": true}`)
+	defer os.Unsetenv("VALUES_B")
+	os.Setenv("VALUES_LIST", "[1, 2]")
+	defer os.Unsetenv("VALUES_LIST")
+
+	d := &data.Data{}
+	c, err = applyValuesFiles(&tmplctx{"name": "ctx", "other": "x"}, []string{
+		"env:///VALUES_A?type=application/yaml",
+		"env:///VALUES_B?type=application/json",
+	}, d)
+	assert.NoError(t, err)
+	assert.Equal(t, &tmplctx{
+		"config": map[string]interface{}{"port": 8080, "host": "example.com"},
+		"name":   "a",
+		"other":  "x",
+		"extra":  true,
+	}, c)
+
+	c, err = applyValuesFiles(map[string]interface{}{}, []string{
+		"env:///VALUES_B?type=application/json",
+	}, &data.Data{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"config": map[string]interface{}{"port": 8080},
+		"extra":  true,
+	}, c)
+
+	_, err = applyValuesFiles(&tmplctx{}, []string{"env:///VALUES_LIST?type=application/yaml"}, &data.Data{})
+	assert.Error(t, err)
+
+	_, err = applyValuesFiles("hello", []string{"env:///VALUES_A?type=application/yaml"}, &data.Data{})
+	assert.Error(t, err)
+}
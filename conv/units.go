@@ -0,0 +1,174 @@
+package conv
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var sizeRe = regexp.MustCompile(`^(\d+(?:\.\d+)?|\.\d+)\s*([a-zA-Z]*)$`)
+
+// sizeUnits - multipliers for size suffixes, keyed by lower-case suffix.
+// Decimal (SI) suffixes are powers of 1000, binary (IEC) suffixes are powers
+// of 1024.
+var sizeUnits = map[string]float64{
+	"": 1, "b": 1,
+	"k": 1e3, "kb": 1e3, "ki": 1 << 10, "kib": 1 << 10,
+	"m": 1e6, "mb": 1e6, "mi": 1 << 20, "mib": 1 << 20,
+	"g": 1e9, "gb": 1e9, "gi": 1 << 30, "gib": 1 << 30,
+	"t": 1e12, "tb": 1e12, "ti": 1 << 40, "tib": 1 << 40,
+	"p": 1e15, "pb": 1e15, "pi": 1 << 50, "pib": 1 << 50,
+	"e": 1e18, "eb": 1e18, "ei": 1 << 60, "eib": 1 << 60,
+}
+
+var binarySizeUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// ParseSize - parse a human-friendly byte size like "10GiB", "1.5M" or "512"
+// into a number of bytes. Decimal suffixes (k, M, G, ...) with an optional "B"
+// are powers of 1000, and binary suffixes (Ki, Mi, Gi, ...) are powers of
+// 1024. Suffixes are case-insensitive.
+func ParseSize(in string) (int64, error) {
+	s := strings.TrimSpace(in)
+	m := sizeRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, errors.Errorf("invalid size %q", in)
+	}
+	mult, ok := sizeUnits[strings.ToLower(m[2])]
+	if !ok {
+		return 0, errors.Errorf("invalid size %q: unknown unit %q", in, m[2])
+	}
+
+	// integers are multiplied exactly, to avoid floating-point rounding
+	if n, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+		if n != 0 && int64(mult) > math.MaxInt64/n {
+			return 0, errors.Errorf("invalid size %q: out of range", in)
+		}
+		return n * int64(mult), nil
+	}
+
+	f, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid size %q", in)
+	}
+	f = math.Round(f * mult)
+	if f >= math.MaxInt64 {
+		return 0, errors.Errorf("invalid size %q: out of range", in)
+	}
+	return int64(f), nil
+}
+
+// HumanSize - format a number of bytes as a human-friendly size with binary
+// units, rounded to at most 2 decimal places (e.g. 123456789 is "117.74MiB")
+func HumanSize(size int64) string {
+	sign := ""
+	f := float64(size)
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+	i := 0
+	for f >= 1024 && i < len(binarySizeUnits)-1 {
+		f /= 1024
+		i++
+	}
+	return sign + strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64) + binarySizeUnits[i]
+}
+
+var durationPartRe = regexp.MustCompile(`^(\d+(?:\.\d*)?|\.\d+)([a-zµμ]+)`)
+
+// ParseDuration - parse a duration string like time.ParseDuration does, but
+// also accepting the units "d" (24 hours) and "w" (7 days)
+func ParseDuration(in string) (time.Duration, error) {
+	s := strings.TrimSpace(in)
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, errors.Errorf("invalid duration %q", in)
+	}
+
+	var d time.Duration
+	for s != "" {
+		m := durationPartRe.FindStringSubmatch(s)
+		if m == nil {
+			return 0, errors.Errorf("invalid duration %q", in)
+		}
+		s = s[len(m[0]):]
+
+		var part time.Duration
+		switch m[2] {
+		case "d", "w":
+			n, err := strconv.ParseFloat(m[1], 64)
+			if err != nil {
+				return 0, errors.Wrapf(err, "invalid duration %q", in)
+			}
+			unit := 24 * time.Hour
+			if m[2] == "w" {
+				unit *= 7
+			}
+			f := n * float64(unit)
+			if f > math.MaxInt64 {
+				return 0, errors.Errorf("invalid duration %q: out of range", in)
+			}
+			part = time.Duration(f)
+		default:
+			var err error
+			part, err = time.ParseDuration(m[0])
+			if err != nil {
+				return 0, errors.Wrapf(err, "invalid duration %q", in)
+			}
+		}
+		if d > math.MaxInt64-part {
+			return 0, errors.Errorf("invalid duration %q: out of range", in)
+		}
+		d += part
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// HumanDuration - format a duration using days, hours, minutes and seconds,
+// omitting zero-valued units (e.g. 36h is "1d12h"). Durations shorter than a
+// second are formatted as with time.Duration's String method.
+func HumanDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	if d < time.Second {
+		return sign + d.String()
+	}
+
+	out := &strings.Builder{}
+	out.WriteString(sign)
+	for _, u := range []struct {
+		unit time.Duration
+		name string
+	}{{24 * time.Hour, "d"}, {time.Hour, "h"}, {time.Minute, "m"}} {
+		if n := d / u.unit; n > 0 {
+			out.WriteString(strconv.FormatInt(int64(n), 10))
+			out.WriteString(u.name)
+			d -= n * u.unit
+		}
+	}
+	if d > 0 {
+		out.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64))
+		out.WriteString("s")
+	}
+	return out.String()
+}
@@ -0,0 +1,104 @@
+package conv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSize(t *testing.T) {
+	testdata := []struct {
+		in       string
+		expected int64
+	}{
+		{"0", 0},
+		{"512", 512},
+		{"512B", 512},
+		{"1k", 1000},
+		{"1KB", 1000},
+		{"1Ki", 1024},
+		{"1KiB", 1024},
+		{"10GiB", 10 * 1024 * 1024 * 1024},
+		{"10gib", 10 * 1024 * 1024 * 1024},
+		{"10G", 10000000000},
+		{"1.5M", 1500000},
+		{"1.5MiB", 1572864},
+		{".5Ki", 512},
+		{" 2 TiB ", 2 << 40},
+		{"7EiB", 7 << 60},
+	}
+	for _, d := range testdata {
+		actual, err := ParseSize(d.in)
+		assert.NoError(t, err, d.in)
+		assert.Equal(t, d.expected, actual, d.in)
+	}
+
+	for _, in := range []string{"", "GiB", "-1", "1XB", "1.2.3M", "8EiB", "10000000000E"} {
+		_, err := ParseSize(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestHumanSize(t *testing.T) {
+	testdata := []struct {
+		in       int64
+		expected string
+	}{
+		{0, "0B"},
+		{500, "500B"},
+		{1024, "1KiB"},
+		{1536, "1.5KiB"},
+		{123456789, "117.74MiB"},
+		{10 << 30, "10GiB"},
+		{-2048, "-2KiB"},
+		{1 << 62, "4EiB"},
+	}
+	for _, d := range testdata {
+		assert.Equal(t, d.expected, HumanSize(d.in), d.in)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	testdata := []struct {
+		in       string
+		expected time.Duration
+	}{
+		{"0", 0},
+		{"90s", 90 * time.Second},
+		{"1h30m", 90 * time.Minute},
+		{"2d", 48 * time.Hour},
+		{"1w", 7 * 24 * time.Hour},
+		{"1w2d3h", (9*24 + 3) * time.Hour},
+		{"1.5d", 36 * time.Hour},
+		{"-1d12h", -36 * time.Hour},
+		{"+500ms", 500 * time.Millisecond},
+	}
+	for _, d := range testdata {
+		actual, err := ParseDuration(d.in)
+		assert.NoError(t, err, d.in)
+		assert.Equal(t, d.expected, actual, d.in)
+	}
+
+	for _, in := range []string{"", "-", "1", "d", "1y", "1d foo", "100000000w"} {
+		_, err := ParseDuration(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestHumanDuration(t *testing.T) {
+	testdata := []struct {
+		in       time.Duration
+		expected string
+	}{
+		{0, "0s"},
+		{500 * time.Millisecond, "500ms"},
+		{90 * time.Second, "1m30s"},
+		{36 * time.Hour, "1d12h"},
+		{(9*24+3)*time.Hour + 1500*time.Millisecond, "9d3h1.5s"},
+		{-36 * time.Hour, "-1d12h"},
+	}
+	for _, d := range testdata {
+		assert.Equal(t, d.expected, HumanDuration(d.in), d.in)
+	}
+}
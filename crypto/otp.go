@@ -0,0 +1,108 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint: gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// OTPOpts - options for HOTP and TOTP codes. The zero values are replaced with
+// the common defaults, which are compatible with most authenticator apps.
+type OTPOpts struct {
+	// Digits - the number of digits in the code, from 6 (the default) to 8
+	Digits int
+	// Algorithm - the HMAC hash: "SHA1" (the default), "SHA256", or "SHA512"
+	Algorithm string
+	// Period - the TOTP time step (defaults to 30 seconds)
+	Period time.Duration
+}
+
+func (o OTPOpts) withDefaults() (OTPOpts, func() hash.Hash, error) {
+	if o.Digits == 0 {
+		o.Digits = 6
+	}
+	if o.Digits < 6 || o.Digits > 8 {
+		return o, nil, errors.Errorf("invalid number of OTP digits %d: must be between 6 and 8", o.Digits)
+	}
+	if o.Period == 0 {
+		o.Period = 30 * time.Second
+	}
+	if o.Period < time.Second {
+		return o, nil, errors.Errorf("invalid TOTP period %s: must be at least 1s", o.Period)
+	}
+	var h func() hash.Hash
+	switch strings.ToUpper(o.Algorithm) {
+	case "", "SHA1":
+		h = sha1.New
+	case "SHA256":
+		h = sha256.New
+	case "SHA512":
+		h = sha512.New
+	default:
+		return o, nil, errors.Errorf("unsupported OTP algorithm %q: must be SHA1, SHA256, or SHA512", o.Algorithm)
+	}
+	return o, h, nil
+}
+
+// DecodeOTPSecret - decode a base32-encoded OTP secret, as given by
+// enrollment APIs and in otpauth:// URIs. Padding is optional, and spaces,
+// hyphens, and case are ignored.
+func DecodeOTPSecret(secret string) ([]byte, error) {
+	s := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(secret))
+	s = strings.TrimRight(s, "=")
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid OTP secret: must be base32-encoded")
+	}
+	if len(key) == 0 {
+		return nil, errors.New("invalid OTP secret: must not be empty")
+	}
+	return key, nil
+}
+
+// HOTP - compute the HMAC-based one-time password (RFC 4226) for the key and
+// counter
+func HOTP(key []byte, counter uint64, opts OTPOpts) (string, error) {
+	opts, h, err := opts.withDefaults()
+	if err != nil {
+		return "", err
+	}
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+	mac := hmac.New(h, key)
+	_, _ = mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < opts.Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", opts.Digits, code%mod), nil
+}
+
+// TOTP - compute the time-based one-time password (RFC 6238) for the key at
+// the given time
+func TOTP(key []byte, t time.Time, opts OTPOpts) (string, error) {
+	opts, _, err := opts.withDefaults()
+	if err != nil {
+		return "", err
+	}
+	if t.Unix() < 0 {
+		return "", errors.Errorf("invalid TOTP time %s: must not be before the Unix epoch", t)
+	}
+	counter := uint64(t.Unix()) / uint64(opts.Period/time.Second)
+	return HOTP(key, counter, opts)
+}
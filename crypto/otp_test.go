@@ -0,0 +1,89 @@
+package crypto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeOTPSecret(t *testing.T) {
+	testdata := []string{
+		"GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ",
+		"gezdgnbvgy3tqojqgezdgnbvgy3tqojq",
+		"GEZD GNBV GY3T QOJQ GEZD GNBV GY3T QOJQ",
+		"GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ====",
+	}
+	for _, d := range testdata {
+		key, err := DecodeOTPSecret(d)
+		assert.NoError(t, err, d)
+		assert.Equal(t, []byte("12345678901234567890"), key, d)
+	}
+
+	_, err := DecodeOTPSecret("not base32!")
+	assert.Error(t, err)
+	_, err = DecodeOTPSecret("")
+	assert.Error(t, err)
+}
+
+func TestHOTP(t *testing.T) {
+	// test vectors from RFC 4226, Appendix D
+	key := []byte("12345678901234567890")
+	expected := []string{
+		"755224", "287082", "359152", "969429", "338314",
+		"254676", "287922", "162583", "399871", "520489",
+	}
+	for i, e := range expected {
+		code, err := HOTP(key, uint64(i), OTPOpts{})
+		assert.NoError(t, err)
+		assert.Equal(t, e, code, "counter %d", i)
+	}
+
+	_, err := HOTP(key, 0, OTPOpts{Digits: 4})
+	assert.Error(t, err)
+	_, err = HOTP(key, 0, OTPOpts{Algorithm: "MD5"})
+	assert.Error(t, err)
+}
+
+func TestTOTP(t *testing.T) {
+	// test vectors from RFC 6238, Appendix B
+	keys := map[string][]byte{
+		"SHA1":   []byte("12345678901234567890"),
+		"SHA256": []byte("12345678901234567890123456789012"),
+		"SHA512": []byte("1234567890123456789012345678901234567890123456789012345678901234"),
+	}
+	testdata := []struct {
+		t    int64
+		alg  string
+		code string
+	}{
+		{59, "SHA1", "94287082"},
+		{59, "SHA256", "46119246"},
+		{59, "SHA512", "90693936"},
+		{1111111109, "SHA1", "07081804"},
+		{1111111109, "SHA256", "68084774"},
+		{1111111109, "SHA512", "25091201"},
+		{1111111111, "SHA1", "14050471"},
+		{1234567890, "SHA256", "91819424"},
+		{2000000000, "SHA512", "38618901"},
+		{20000000000, "SHA1", "65353130"},
+	}
+	for _, d := range testdata {
+		code, err := TOTP(keys[d.alg], time.Unix(d.t, 0), OTPOpts{Digits: 8, Algorithm: d.alg})
+		assert.NoError(t, err)
+		assert.Equal(t, d.code, code, "%d %s", d.t, d.alg)
+	}
+
+	code, err := TOTP(keys["SHA1"], time.Unix(59, 0), OTPOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	code, err = TOTP(keys["SHA1"], time.Unix(59, 0), OTPOpts{Period: 60 * time.Second})
+	assert.NoError(t, err)
+	assert.Equal(t, "755224", code)
+
+	_, err = TOTP(keys["SHA1"], time.Unix(-1, 0), OTPOpts{})
+	assert.Error(t, err)
+	_, err = TOTP(keys["SHA1"], time.Unix(59, 0), OTPOpts{Period: time.Millisecond})
+	assert.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package crypto
+
+import (
+	"bufio"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+// AuthorizedKey - an entry in an authorized_keys (or known_hosts) file
+type AuthorizedKey struct {
+	// Type - the key type, e.g. "ssh-ed25519"
+	Type string `json:"type"`
+	// Key - the base64-encoded public key
+	Key string `json:"key"`
+	// Comment - the key's comment, often user@host
+	Comment string `json:"comment"`
+	// Options - any options (e.g. `from="10.0.0.0/8"`) preceding the key
+	Options []string `json:"options"`
+	// Fingerprint - the SHA256 fingerprint, as shown by ssh-keygen
+	Fingerprint string `json:"fingerprint"`
+}
+
+// ParsePublicKey - parse an SSH public key in authorized_keys format (e.g.
+// "ssh-ed25519 AAAA... user@host"), a PEM-encoded PKIX ("PUBLIC KEY") or
+// PKCS#1 ("RSA PUBLIC KEY") public key, or a PEM-encoded private key (RSA,
+// ECDSA, Ed25519, or OpenSSH format), in which case its public key is returned
+func ParsePublicKey(in []byte) (pub ssh.PublicKey, comment string, err error) {
+	if block, _ := pem.Decode(in); block != nil {
+		pub, err = pemPublicKey(in, block)
+		return pub, "", err
+	}
+	pub, comment, _, _, err = ssh.ParseAuthorizedKey(in)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to parse SSH public key")
+	}
+	return pub, comment, nil
+}
+
+func pemPublicKey(in []byte, block *pem.Block) (ssh.PublicKey, error) {
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		var priv interface{}
+		priv, err = ssh.ParseRawPrivateKey(in)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse private key")
+		}
+		signer, serr := ssh.NewSignerFromKey(priv)
+		if serr != nil {
+			return nil, serr
+		}
+		return signer.PublicKey(), nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", block.Type)
+	}
+	return ssh.NewPublicKey(key)
+}
+
+// SSHFingerprint - the fingerprint of the public key, as shown by ssh-keygen.
+// The hash can be "sha256" (the default, e.g. "SHA256:...") or "md5" (the
+// legacy colon-separated hex format).
+func SSHFingerprint(hash string, in []byte) (string, error) {
+	pub, _, err := ParsePublicKey(in)
+	if err != nil {
+		return "", err
+	}
+	switch strings.ToLower(hash) {
+	case "", "sha256":
+		return ssh.FingerprintSHA256(pub), nil
+	case "md5":
+		return "MD5:" + ssh.FingerprintLegacyMD5(pub), nil
+	default:
+		return "", errors.Errorf("unsupported fingerprint hash %q: must be sha256 or md5", hash)
+	}
+}
+
+// ParseAuthorizedKeys - parse the entries in an authorized_keys file.
+// Comments and blank lines are skipped.
+func ParseAuthorizedKeys(in []byte) ([]AuthorizedKey, error) {
+	keys := []AuthorizedKey{}
+	s := bufio.NewScanner(strings.NewReader(string(in)))
+	s.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pub, comment, options, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid authorized key on line %d", n)
+		}
+		if options == nil {
+			options = []string{}
+		}
+		keys = append(keys, AuthorizedKey{
+			Type:        pub.Type(),
+			Key:         base64.StdEncoding.EncodeToString(pub.Marshal()),
+			Comment:     comment,
+			Options:     options,
+			Fingerprint: ssh.FingerprintSHA256(pub),
+		})
+	}
+	return keys, s.Err()
+}
+
+// ToAuthorizedKey - format the public key (see ParsePublicKey for the
+// accepted forms) as an authorized_keys line, with an optional comment
+func ToAuthorizedKey(in []byte, comment string) (string, error) {
+	pub, c, err := ParsePublicKey(in)
+	if err != nil {
+		return "", err
+	}
+	if comment == "" {
+		comment = c
+	}
+	out := strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(pub)), "\n")
+	if comment != "" {
+		out += " " + comment
+	}
+	return out, nil
+}
@@ -0,0 +1,370 @@
+package data
+
+import (
+	"encoding/csv"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hairyhenderson/gomplate/v3/conv"
+	"github.com/pkg/errors"
+)
+
+// CSVOptions - options for reading and writing CSV. Use DefaultCSVOptions to
+// get the defaults, which are the same as RFC 4180.
+type CSVOptions struct {
+	// Header - the column names. On read, when nil the first row is the
+	// header, and when empty the columns are named A, B, C, etc... On write,
+	// when set the header is written first.
+	Header []string
+	// Delimiter - the field delimiter
+	Delimiter rune
+	// Quote - the quote character
+	Quote rune
+	// Comment - lines starting with this character are ignored on read. Zero
+	// disables comments.
+	Comment rune
+	// TrimLeadingSpace - ignore leading white space in fields on read
+	TrimLeadingSpace bool
+	// LazyQuotes - allow quotes in unquoted fields, and non-doubled quotes
+	// in quoted fields on read
+	LazyQuotes bool
+	// QuoteAll - quote all fields on write, rather than only where needed
+	QuoteAll bool
+	// CRLF - end lines with \r\n on write, rather than \n
+	CRLF bool
+}
+
+// DefaultCSVOptions -
+func DefaultCSVOptions() CSVOptions {
+	return CSVOptions{Delimiter: ',', Quote: '"', CRLF: true}
+}
+
+// ParseCSVOptions - build CSVOptions from a map, as given in a template. The
+// keys are the CSVOptions field names, in camelCase. Characters must be
+// single-character strings, and the header can be a list of names or a
+// delimited string.
+func ParseCSVOptions(in map[string]interface{}) (CSVOptions, error) {
+	opts := DefaultCSVOptions()
+	var err error
+	for k, v := range in {
+		switch k {
+		case "delimiter":
+			opts.Delimiter, err = csvChar(k, v, false)
+		case "quote":
+			opts.Quote, err = csvChar(k, v, false)
+		case "comment":
+			opts.Comment, err = csvChar(k, v, true)
+		case "header":
+		case "trimLeadingSpace":
+			opts.TrimLeadingSpace = conv.ToBool(v)
+		case "lazyQuotes":
+			opts.LazyQuotes = conv.ToBool(v)
+		case "quoteAll":
+			opts.QuoteAll = conv.ToBool(v)
+		case "crlf":
+			opts.CRLF = conv.ToBool(v)
+		default:
+			return opts, errors.Errorf("unknown CSV option %q", k)
+		}
+		if err != nil {
+			return opts, err
+		}
+	}
+
+	// the header may need the delimiter, so it's handled last
+	if v, ok := in["header"]; ok {
+		switch h := v.(type) {
+		case string:
+			opts.Header = []string{}
+			if h != "" {
+				opts.Header = strings.Split(h, string(opts.Delimiter))
+			}
+		case []string:
+			opts.Header = h
+		case []interface{}:
+			opts.Header = conv.ToStrings(h...)
+		default:
+			return opts, errors.Errorf("invalid CSV header: must be a list or a string, got %T", v)
+		}
+	}
+
+	return opts, opts.validate()
+}
+
+func csvChar(name string, v interface{}, allowEmpty bool) (rune, error) {
+	s := conv.ToString(v)
+	if s == "" && allowEmpty {
+		return 0, nil
+	}
+	if utf8.RuneCountInString(s) != 1 {
+		return 0, errors.Errorf("invalid CSV %s %q: must be a single character", name, s)
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	return r, nil
+}
+
+func (o CSVOptions) validate() error {
+	bad := func(r rune) bool {
+		return r == '\r' || r == '\n' || r == utf8.RuneError
+	}
+	if bad(o.Delimiter) || bad(o.Quote) || bad(o.Comment) {
+		return errors.New("invalid CSV options: delimiter, quote and comment characters must be valid, and not line breaks")
+	}
+	if o.Delimiter == o.Quote || o.Delimiter == o.Comment || o.Quote == o.Comment {
+		return errors.New("invalid CSV options: delimiter, quote and comment characters must be different")
+	}
+	return nil
+}
+
+func (o CSVOptions) withDefaults() CSVOptions {
+	if o.Delimiter == 0 {
+		o.Delimiter = ','
+	}
+	if o.Quote == 0 {
+		o.Quote = '"'
+	}
+	return o
+}
+
+// CSVWithOptions - Unmarshal CSV, like CSV, with the given options
+func CSVWithOptions(in string, opts CSVOptions) ([][]string, error) {
+	records, hdr, err := parseCSVWithOptions(in, opts)
+	if err != nil {
+		return nil, err
+	}
+	return append([][]string{hdr}, records...), nil
+}
+
+// CSVByRowWithOptions - Unmarshal CSV in a row-oriented form, like CSVByRow,
+// with the given options
+func CSVByRowWithOptions(in string, opts CSVOptions) ([]map[string]string, error) {
+	records, hdr, err := parseCSVWithOptions(in, opts)
+	if err != nil {
+		return nil, err
+	}
+	return csvRows(records, hdr), nil
+}
+
+// CSVByColumnWithOptions - Unmarshal CSV in a columnar form, like
+// CSVByColumn, with the given options
+func CSVByColumnWithOptions(in string, opts CSVOptions) (map[string][]string, error) {
+	records, hdr, err := parseCSVWithOptions(in, opts)
+	if err != nil {
+		return nil, err
+	}
+	return csvColumns(records, hdr), nil
+}
+
+func csvRows(records [][]string, hdr []string) (rows []map[string]string) {
+	for _, record := range records {
+		m := make(map[string]string)
+		for i, v := range record {
+			m[hdr[i]] = v
+		}
+		rows = append(rows, m)
+	}
+	return rows
+}
+
+func csvColumns(records [][]string, hdr []string) map[string][]string {
+	cols := make(map[string][]string)
+	for _, record := range records {
+		for i, v := range record {
+			cols[hdr[i]] = append(cols[hdr[i]], v)
+		}
+	}
+	return cols
+}
+
+func parseCSVWithOptions(in string, opts CSVOptions) ([][]string, []string, error) {
+	opts = opts.withDefaults()
+	if err := opts.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	// encoding/csv only supports '"' as the quote character, so any other
+	// quote character is swapped with '"' in the input, and back again in
+	// the parsed fields
+	swap := func(r rune) rune { return r }
+	if opts.Quote != '"' {
+		swap = func(r rune) rune {
+			switch r {
+			case opts.Quote:
+				return '"'
+			case '"':
+				return opts.Quote
+			}
+			return r
+		}
+		in = strings.Map(swap, in)
+	}
+
+	c := csv.NewReader(strings.NewReader(in))
+	c.Comma = swap(opts.Delimiter)
+	if opts.Comment != 0 {
+		c.Comment = swap(opts.Comment)
+	}
+	c.TrimLeadingSpace = opts.TrimLeadingSpace
+	c.LazyQuotes = opts.LazyQuotes
+	records, err := c.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.Quote != '"' {
+		for _, record := range records {
+			for i, v := range record {
+				record[i] = strings.Map(swap, v)
+			}
+		}
+	}
+
+	hdr := opts.Header
+	if len(records) > 0 {
+		if hdr == nil {
+			hdr = records[0]
+			records = records[1:]
+		} else if len(hdr) == 0 {
+			hdr = make([]string, len(records[0]))
+			for i := range hdr {
+				hdr[i] = autoIndex(i)
+			}
+		}
+	}
+	return records, hdr, nil
+}
+
+// ToCSVWithOptions - marshal to CSV, like ToCSV, with the given options. As
+// well as two-dimensional arrays, the input can be an array of maps, in which
+// case the header is written first. The columns are the header option's
+// names if set, or else the maps' keys in sorted order.
+func ToCSVWithOptions(in interface{}, opts CSVOptions) (string, error) {
+	opts = opts.withDefaults()
+	if err := opts.validate(); err != nil {
+		return "", err
+	}
+
+	records, err := toCSVRecords(in, opts.Header)
+	if err != nil {
+		return "", err
+	}
+
+	eol := "\n"
+	if opts.CRLF {
+		eol = "\r\n"
+	}
+	q := string(opts.Quote)
+	b := &strings.Builder{}
+	for _, record := range records {
+		for i, field := range record {
+			if i > 0 {
+				b.WriteRune(opts.Delimiter)
+			}
+			if !opts.QuoteAll && !csvNeedsQuotes(field, opts) {
+				b.WriteString(field)
+				continue
+			}
+			field = strings.ReplaceAll(field, q, q+q)
+			// line breaks within fields are normalized to the line ending
+			field = strings.ReplaceAll(field, "\r\n", "\n")
+			field = strings.ReplaceAll(field, "\n", eol)
+			b.WriteString(q + field + q)
+		}
+		b.WriteString(eol)
+	}
+	return b.String(), nil
+}
+
+// csvNeedsQuotes - this follows encoding/csv's rules, plus fields starting
+// with the comment character
+func csvNeedsQuotes(field string, opts CSVOptions) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsAny(field, string([]rune{opts.Delimiter, opts.Quote, '\r', '\n'})) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(field)
+	return unicode.IsSpace(r) || (opts.Comment != 0 && r == opts.Comment)
+}
+
+func toCSVRecords(in interface{}, hdr []string) ([][]string, error) {
+	withHeader := func(records [][]string) [][]string {
+		if len(hdr) > 0 {
+			return append([][]string{hdr}, records...)
+		}
+		return records
+	}
+	switch a := in.(type) {
+	case [][]string:
+		return withHeader(a), nil
+	case [][]interface{}:
+		out := make([][]string, len(a))
+		for i, v := range a {
+			out[i] = conv.ToStrings(v...)
+		}
+		return withHeader(out), nil
+	case []map[string]string:
+		rows := make([]map[string]interface{}, len(a))
+		for i, m := range a {
+			rows[i] = make(map[string]interface{}, len(m))
+			for k, v := range m {
+				rows[i][k] = v
+			}
+		}
+		return mapsToCSVRecords(rows, hdr), nil
+	case []map[string]interface{}:
+		return mapsToCSVRecords(a, hdr), nil
+	case []interface{}:
+		out := make([][]string, len(a))
+		var rows []map[string]interface{}
+		for i, v := range a {
+			switch r := v.(type) {
+			case []interface{}:
+				out[i] = conv.ToStrings(r...)
+			case []string:
+				out[i] = r
+			case map[string]interface{}:
+				rows = append(rows, r)
+			default:
+				return nil, errors.Errorf("Can't parse ToCSV input - must be a two-dimensional array (like [][]string or [][]interface{}) or an array of maps (was %T)", in)
+			}
+		}
+		if rows == nil {
+			return withHeader(out), nil
+		}
+		if len(rows) != len(a) {
+			return nil, errors.New("Can't parse ToCSV input - arrays and maps can't be mixed")
+		}
+		return mapsToCSVRecords(rows, hdr), nil
+	default:
+		return nil, errors.Errorf("Can't parse ToCSV input - must be a two-dimensional array (like [][]string or [][]interface{}) or an array of maps (was %T)", in)
+	}
+}
+
+func mapsToCSVRecords(rows []map[string]interface{}, hdr []string) [][]string {
+	if len(hdr) == 0 {
+		keys := map[string]bool{}
+		for _, row := range rows {
+			for k := range row {
+				if !keys[k] {
+					keys[k] = true
+					hdr = append(hdr, k)
+				}
+			}
+		}
+		sort.Strings(hdr)
+	}
+	out := [][]string{hdr}
+	for _, row := range rows {
+		record := make([]string, len(hdr))
+		for i, k := range hdr {
+			if v, ok := row[k]; ok {
+				record[i] = conv.ToString(v)
+			}
+		}
+		out = append(out, record)
+	}
+	return out
+}
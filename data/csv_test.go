@@ -0,0 +1,160 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSVOptions(t *testing.T) {
+	opts, err := ParseCSVOptions(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultCSVOptions(), opts)
+
+	opts, err = ParseCSVOptions(map[string]interface{}{
+		"delimiter":        ";",
+		"quote":            "'",
+		"comment":          "#",
+		"header":           "a;b",
+		"trimLeadingSpace": true,
+		"lazyQuotes":       "true",
+		"quoteAll":         true,
+		"crlf":             false,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, CSVOptions{
+		Header:           []string{"a", "b"},
+		Delimiter:        ';',
+		Quote:            '\'',
+		Comment:          '#',
+		TrimLeadingSpace: true,
+		LazyQuotes:       true,
+		QuoteAll:         true,
+	}, opts)
+
+	opts, err = ParseCSVOptions(map[string]interface{}{"header": []interface{}{"x", "y"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"x", "y"}, opts.Header)
+
+	opts, err = ParseCSVOptions(map[string]interface{}{"header": ""})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, opts.Header)
+
+	testdata := []map[string]interface{}{
+		{"delimiter": ";;"},
+		{"delimiter": ""},
+		{"quote": ","},
+		{"comment": "\n"},
+		{"header": 42},
+		{"bogus": true},
+	}
+	for _, d := range testdata {
+		_, err = ParseCSVOptions(d)
+		assert.Error(t, err, "%v", d)
+	}
+}
+
+func TestCSVWithOptions(t *testing.T) {
+	in := "# exported 2020-04-01\nname;price;note\n'Widget';'1,50';'say ''hi'''\n\"Gadget\";2,00;\n"
+	opts := DefaultCSVOptions()
+	opts.Delimiter = ';'
+	opts.Quote = '\''
+	opts.Comment = '#'
+
+	out, err := CSVWithOptions(in, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"name", "price", "note"},
+		{"Widget", "1,50", "say 'hi'"},
+		{`"Gadget"`, "2,00", ""},
+	}, out)
+
+	rows, err := CSVByRowWithOptions(in, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"name": "Widget", "price": "1,50", "note": "say 'hi'"},
+		{"name": `"Gadget"`, "price": "2,00", "note": ""},
+	}, rows)
+
+	cols, err := CSVByColumnWithOptions("a; b\nc; d\n", CSVOptions{Delimiter: ';', Header: []string{}, TrimLeadingSpace: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"A": {"a", "c"}, "B": {"b", "d"}}, cols)
+
+	_, err = CSVWithOptions(`a,"b`, DefaultCSVOptions())
+	assert.Error(t, err)
+
+	_, err = CSVWithOptions(`a,b`, CSVOptions{Delimiter: '"'})
+	assert.Error(t, err)
+
+	out, err = CSVWithOptions(`a,b"c`, CSVOptions{LazyQuotes: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", `b"c`}}, out)
+}
+
+func TestToCSVWithOptions(t *testing.T) {
+	in := [][]interface{}{
+		{"name", "price", "note"},
+		{"Widget", "1,50", "say 'hi'"},
+		{"Gadget", 2, ""},
+	}
+	opts := DefaultCSVOptions()
+	opts.Delimiter = ';'
+	opts.Quote = '\''
+	opts.CRLF = false
+	out, err := ToCSVWithOptions(in, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "name;price;note\nWidget;1,50;'say ''hi'''\nGadget;2;\n", out)
+
+	// round-trip
+	records, err := CSVWithOptions(out, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"name", "price", "note"},
+		{"Widget", "1,50", "say 'hi'"},
+		{"Gadget", "2", ""},
+	}, records)
+
+	opts = DefaultCSVOptions()
+	opts.QuoteAll = true
+	out, err = ToCSVWithOptions([][]string{{"a", "b\nc"}}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "\"a\",\"b\r\nc\"\r\n", out)
+
+	opts = DefaultCSVOptions()
+	opts.Comment = '#'
+	out, err = ToCSVWithOptions([][]string{{"#a", " b", "c;d"}}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "\"#a\",\" b\",c;d\r\n", out)
+
+	opts = DefaultCSVOptions()
+	opts.Header = []string{"x", "y"}
+	out, err = ToCSVWithOptions([][]string{{"1", "2"}}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "x,y\r\n1,2\r\n", out)
+}
+
+func TestToCSVWithOptions_Maps(t *testing.T) {
+	rows := []interface{}{
+		map[string]interface{}{"name": "Widget", "price": 1.5},
+		map[string]interface{}{"name": "Gadget", "qty": 3},
+	}
+	out, err := ToCSVWithOptions(rows, DefaultCSVOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, "name,price,qty\r\nWidget,1.5,\r\nGadget,,3\r\n", out)
+
+	opts := DefaultCSVOptions()
+	opts.Header = []string{"qty", "name"}
+	out, err = ToCSVWithOptions(rows, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "qty,name\r\n,Widget\r\n3,Gadget\r\n", out)
+
+	out, err = ToCSVWithOptions([]map[string]string{{"a": "1"}}, DefaultCSVOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, "a\r\n1\r\n", out)
+
+	_, err = ToCSVWithOptions([]interface{}{map[string]interface{}{}, []interface{}{}}, DefaultCSVOptions())
+	assert.Error(t, err)
+
+	_, err = ToCSVWithOptions(42, DefaultCSVOptions())
+	assert.Error(t, err)
+}
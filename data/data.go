@@ -6,7 +6,6 @@ package data
 
 import (
 	"bytes"
-	"encoding/csv"
 	"encoding/json"
 	"io"
 	"strings"
@@ -15,7 +14,6 @@ import (
 
 	"github.com/Shopify/ejson"
 	ejsonJson "github.com/Shopify/ejson/json"
-	"github.com/hairyhenderson/gomplate/v3/conv"
 	"github.com/hairyhenderson/gomplate/v3/env"
 
 	// XXX: replace once https://github.com/BurntSushi/toml/pull/179 is merged
@@ -144,24 +142,10 @@ func dotEnv(in string) (interface{}, error) {
 
 func parseCSV(args ...string) ([][]string, []string, error) {
 	in, delim, hdr := csvParseArgs(args...)
-	c := csv.NewReader(strings.NewReader(in))
-	c.Comma = rune(delim[0])
-	records, err := c.ReadAll()
-	if err != nil {
-		return nil, nil, err
-	}
-	if len(records) > 0 {
-		if hdr == nil {
-			hdr = records[0]
-			records = records[1:]
-		} else if len(hdr) == 0 {
-			hdr = make([]string, len(records[0]))
-			for i := range hdr {
-				hdr[i] = autoIndex(i)
-			}
-		}
-	}
-	return records, hdr, nil
+	opts := DefaultCSVOptions()
+	opts.Delimiter = rune(delim[0])
+	opts.Header = hdr
+	return parseCSVWithOptions(in, opts)
 }
 
 func csvParseArgs(args ...string) (in, delim string, hdr []string) {
@@ -227,14 +211,7 @@ func CSVByRow(args ...string) (rows []map[string]string, err error) {
 	if err != nil {
 		return nil, err
 	}
-	for _, record := range records {
-		m := make(map[string]string)
-		for i, v := range record {
-			m[hdr[i]] = v
-		}
-		rows = append(rows, m)
-	}
-	return rows, nil
+	return csvRows(records, hdr), nil
 }
 
 // CSVByColumn - Unmarshal CSV in a Columnar form
@@ -251,59 +228,25 @@ func CSVByColumn(args ...string) (cols map[string][]string, err error) {
 	if err != nil {
 		return nil, err
 	}
-	cols = make(map[string][]string)
-	for _, record := range records {
-		for i, v := range record {
-			cols[hdr[i]] = append(cols[hdr[i]], v)
-		}
-	}
-	return cols, nil
+	return csvColumns(records, hdr), nil
 }
 
 // ToCSV -
 func ToCSV(args ...interface{}) (string, error) {
-	delim := ","
-	var in [][]string
+	opts := DefaultCSVOptions()
 	if len(args) == 2 {
-		var ok bool
-		delim, ok = args[0].(string)
+		delim, ok := args[0].(string)
 		if !ok {
 			return "", errors.Errorf("Can't parse ToCSV delimiter (%v) - must be string (is a %T)", args[0], args[0])
 		}
+		opts.Delimiter = rune(delim[0])
 		args = args[1:]
 	}
-	if len(args) == 1 {
-		switch a := args[0].(type) {
-		case [][]string:
-			in = a
-		case [][]interface{}:
-			in = make([][]string, len(a))
-			for i, v := range a {
-				in[i] = conv.ToStrings(v...)
-			}
-		case []interface{}:
-			in = make([][]string, len(a))
-			for i, v := range a {
-				ar, ok := v.([]interface{})
-				if !ok {
-					return "", errors.Errorf("Can't parse ToCSV input - must be a two-dimensional array (like [][]string or [][]interface{}) (was %T)", args[0])
-				}
-				in[i] = conv.ToStrings(ar...)
-			}
-		default:
-			return "", errors.Errorf("Can't parse ToCSV input - must be a two-dimensional array (like [][]string or [][]interface{}) (was %T)", args[0])
-		}
+	if len(args) != 1 {
+		return "", errors.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
 	}
-	b := &bytes.Buffer{}
-	c := csv.NewWriter(b)
-	c.Comma = rune(delim[0])
 	// We output RFC4180 CSV, so force this to CRLF
-	c.UseCRLF = true
-	err := c.WriteAll(in)
-	if err != nil {
-		return "", err
-	}
-	return b.String(), nil
+	return ToCSVWithOptions(args[0], opts)
 }
 
 func marshalObj(obj interface{}, f func(interface{}) ([]byte, error)) (string, error) {
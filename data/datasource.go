@@ -11,6 +11,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/afero"
 
@@ -86,7 +87,7 @@ type Data struct {
 	Sources map[string]*Source
 
 	sourceReaders map[string]func(*Source, ...string) ([]byte, error)
-	cache         map[string][]byte
+	cache         map[string]cachedData
 
 	// headers from the --datasource-header/-H option that don't reference datasources from the commandline
 	extraHeaders map[string]http.Header
@@ -130,18 +131,10 @@ func NewData(datasourceArgs, headerArgs []string) (*Data, error) {
 func FromConfig(cfg *config.Config) *Data {
 	sources := map[string]*Source{}
 	for alias, d := range cfg.DataSources {
-		sources[alias] = &Source{
-			Alias:  alias,
-			URL:    d.URL,
-			header: d.Header,
-		}
+		sources[alias] = sourceFromConfig(alias, d)
 	}
 	for alias, d := range cfg.Context {
-		sources[alias] = &Source{
-			Alias:  alias,
-			URL:    d.URL,
-			header: d.Header,
-		}
+		sources[alias] = sourceFromConfig(alias, d)
 	}
 	return &Data{
 		Sources:      sources,
@@ -149,6 +142,21 @@ func FromConfig(cfg *config.Config) *Data {
 	}
 }
 
+func sourceFromConfig(alias string, d config.DSConfig) *Source {
+	return &Source{
+		Alias:            alias,
+		URL:              d.URL,
+		header:           d.Header,
+		mimeTypeOverride: d.Type,
+		timeout:          d.Timeout,
+		retries:          d.Retries,
+		auth:             d.Auth,
+		cacheTTL:         d.CacheTTL,
+		optional:         d.Optional,
+		defaultValue:     d.Default,
+	}
+}
+
 // Source - a data source
 type Source struct {
 	Alias             string
@@ -161,6 +169,15 @@ type Source struct {
 	asmpg             awssmpGetter            // used for aws+smp:, nil otherwise
 	awsSecretsManager awsSecretsManagerGetter // used for aws+sm, nil otherwise
 	header            http.Header             // used for http[s]: URLs, nil otherwise
+
+	// options set in the config file
+	mimeTypeOverride string
+	timeout          time.Duration  // used for http[s]: URLs
+	retries          int            // how many times to retry failed reads
+	auth             *config.DSAuth // used for http[s]: URLs
+	cacheTTL         time.Duration  // 0 means cache until exit
+	optional         bool           // if set, read errors evaluate to defaultValue
+	defaultValue     interface{}
 }
 
 func (s *Source) inherit(parent *Source) {
@@ -186,9 +203,10 @@ func (s *Source) cleanup() {
 //
 // The MIME type is determined by these rules:
 // 1. the 'type' URL query parameter is used if present
-// 2. otherwise, the Type property on the Source is used, if present
-// 3. otherwise, a MIME type is calculated from the file extension, if the extension is registered
-// 4. otherwise, the default type of 'text/plain' is used
+// 2. otherwise, the type set in the config file is used, if present
+// 3. otherwise, the Type property on the Source is used, if present
+// 4. otherwise, a MIME type is calculated from the file extension, if the extension is registered
+// 5. otherwise, the default type of 'text/plain' is used
 func (s *Source) mimeType(arg string) (mimeType string, err error) {
 	if len(arg) > 0 {
 		if strings.HasPrefix(arg, "//") {
@@ -207,6 +225,9 @@ func (s *Source) mimeType(arg string) (mimeType string, err error) {
 		mediatype = s.URL.Query().Get("type")
 	}
 
+	if mediatype == "" {
+		mediatype = s.mimeTypeOverride
+	}
 	if mediatype == "" {
 		mediatype = s.mediaType
 	}
@@ -400,6 +421,12 @@ func (d *Data) readDataSource(alias string, args ...string) (data, mimeType stri
 // Include -
 func (d *Data) Include(alias string, args ...string) (string, error) {
 	data, _, err := d.readDataSource(alias, args...)
+	if err != nil {
+		if s, ok := d.optionalSource(alias); ok {
+			def, _ := s.defaultValue.(string)
+			return def, nil
+		}
+	}
 	return data, err
 }
 
@@ -407,12 +434,21 @@ func (d *Data) Include(alias string, args ...string) (string, error) {
 func (d *Data) Datasource(alias string, args ...string) (interface{}, error) {
 	data, mimeType, err := d.readDataSource(alias, args...)
 	if err != nil {
+		if s, ok := d.optionalSource(alias); ok {
+			return s.defaultValue, nil
+		}
 		return nil, err
 	}
 
 	return parseData(mimeType, data)
 }
 
+// ParseData - parse the given string according to the given MIME type, the
+// same way as datasource contents are parsed
+func ParseData(mimeType, s string) (interface{}, error) {
+	return parseData(mimeType, s)
+}
+
 func parseData(mimeType, s string) (out interface{}, err error) {
 	switch mimeAlias(mimeType) {
 	case jsonMimetype:
@@ -446,32 +482,70 @@ func (d *Data) DatasourceReachable(alias string, args ...string) bool {
 	return err == nil
 }
 
+// optionalSource - the named source, if it's optional
+func (d *Data) optionalSource(alias string) (*Source, bool) {
+	s, ok := d.Sources[alias]
+	if !ok || !s.optional {
+		return nil, false
+	}
+	return s, true
+}
+
+type cachedData struct {
+	data    []byte
+	expires time.Time // zero if it never expires
+}
+
+// retryDelay - the delay before the first retry of a failed read - it
+// doubles for each subsequent retry
+var retryDelay = 250 * time.Millisecond
+
 // readSource returns the (possibly cached) data from the given source,
 // as referenced by the given args
 func (d *Data) readSource(source *Source, args ...string) ([]byte, error) {
 	if d.cache == nil {
-		d.cache = make(map[string][]byte)
+		d.cache = make(map[string]cachedData)
 	}
 	cacheKey := source.Alias
 	for _, v := range args {
 		cacheKey += v
 	}
 	cached, ok := d.cache[cacheKey]
-	if ok {
-		return cached, nil
+	if ok && (cached.expires.IsZero() || time.Now().Before(cached.expires)) {
+		return cached.data, nil
 	}
 	r, err := d.lookupReader(source.URL.Scheme)
 	if err != nil {
 		return nil, errors.Wrap(err, "Datasource not yet supported")
 	}
 	data, err := r(source, args...)
+	delay := retryDelay
+	for i := 0; err != nil && i < source.retries; i++ {
+		time.Sleep(delay)
+		delay *= 2
+		data, err = r(source, args...)
+	}
 	if err != nil {
 		return nil, err
 	}
-	d.cache[cacheKey] = data
+	cached = cachedData{data: data}
+	if source.cacheTTL > 0 {
+		cached.expires = time.Now().Add(source.cacheTTL)
+	}
+	d.cache[cacheKey] = cached
 	return data, nil
 }
 
+// ReadURL - read the raw contents of the given URL with the same readers used
+// for datasources, for things that aren't datasources themselves (such as
+// remote config files). The header is only used for HTTP-based URLs.
+func ReadURL(u *url.URL, header http.Header) ([]byte, error) {
+	s := &Source{Alias: u.String(), URL: u, header: header}
+	d := &Data{Sources: map[string]*Source{s.Alias: s}}
+	defer d.Cleanup()
+	return d.readSource(s)
+}
+
 func readStdin(source *Source, args ...string) ([]byte, error) {
 	if stdin == nil {
 		stdin = os.Stdin
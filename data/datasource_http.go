@@ -24,7 +24,11 @@ func buildURL(base *url.URL, args ...string) (*url.URL, error) {
 
 func readHTTP(source *Source, args ...string) ([]byte, error) {
 	if source.hc == nil {
-		source.hc = &http.Client{Timeout: time.Second * 5}
+		timeout := time.Second * 5
+		if source.timeout > 0 {
+			timeout = source.timeout
+		}
+		source.hc = &http.Client{Timeout: timeout}
 	}
 	u, err := buildURL(source.URL, args...)
 	if err != nil {
@@ -35,6 +39,18 @@ func readHTTP(source *Source, args ...string) ([]byte, error) {
 		return nil, err
 	}
 	req.Header = source.header
+	if a := source.auth; a != nil {
+		// copy the header so the source's isn't modified
+		req.Header = source.header.Clone()
+		if req.Header == nil {
+			req.Header = http.Header{}
+		}
+		if a.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+a.Token)
+		} else {
+			req.SetBasicAuth(a.Username, a.Password)
+		}
+	}
 	res, err := source.hc.Do(req)
 	if err != nil {
 		return nil, err
@@ -7,7 +7,9 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
+	"github.com/hairyhenderson/gomplate/v3/internal/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -118,6 +120,53 @@ func TestHTTPFileWithHeaders(t *testing.T) {
 	assert.Equal(t, must(marshalObj(expected, json.Marshal)), must(marshalObj(actual, json.Marshal)))
 }
 
+func TestHTTPFileWithAuth(t *testing.T) {
+	server, client := setupHTTP(200, jsonMimetype, "")
+	defer server.Close()
+
+	header := http.Header{"Foo": {"bar"}}
+	sources := map[string]*Source{
+		"basic": {
+			Alias:  "basic",
+			URL:    mustParseURL("http://example.com/basic"),
+			hc:     client,
+			header: header,
+			auth:   &config.DSAuth{Username: "me", Password: "secret"},
+		},
+		"token": {
+			Alias: "token",
+			URL:   mustParseURL("http://example.com/token"),
+			hc:    client,
+			auth:  &config.DSAuth{Token: "abcd1234"},
+		},
+	}
+	data := &Data{Sources: sources}
+
+	actual, err := data.Datasource("basic")
+	assert.NoError(t, err)
+	hdr := actual.(map[string]interface{})
+	assert.Equal(t, []interface{}{"Basic bWU6c2VjcmV0"}, hdr["Authorization"])
+	assert.Equal(t, []interface{}{"bar"}, hdr["Foo"])
+	// the source's header isn't modified
+	assert.Equal(t, http.Header{"Foo": {"bar"}}, header)
+
+	actual, err = data.Datasource("token")
+	assert.NoError(t, err)
+	hdr = actual.(map[string]interface{})
+	assert.Equal(t, []interface{}{"Bearer abcd1234"}, hdr["Authorization"])
+}
+
+func TestHTTPTimeout(t *testing.T) {
+	s := &Source{
+		Alias:   "slow",
+		URL:     mustParseURL("http://example.com"),
+		timeout: 42 * time.Second,
+	}
+	// the request will fail, but the client is created first
+	_, _ = readHTTP(s, "%zz")
+	assert.Equal(t, 42*time.Second, s.hc.Timeout)
+}
+
 func TestParseHeaderArgs(t *testing.T) {
 	args := []string{
 		"foo=Accept: application/json",
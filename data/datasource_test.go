@@ -1,6 +1,7 @@
 package data
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -9,6 +10,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hairyhenderson/gomplate/v3/internal/config"
 	"github.com/spf13/afero"
@@ -523,4 +525,120 @@ func TestFromConfig(t *testing.T) {
 		},
 	}
 	assert.EqualValues(t, expected, FromConfig(cfg))
+	cfg = &config.Config{
+		DataSources: map[string]config.DSConfig{
+			"foo": {
+				URL:      mustParseURL("http://foo.com"),
+				Type:     jsonMimetype,
+				Timeout:  time.Second,
+				Retries:  2,
+				Auth:     &config.DSAuth{Token: "abcd"},
+				CacheTTL: time.Minute,
+				Optional: true,
+				Default:  "bar",
+			},
+		},
+	}
+	expected = &Data{
+		Sources: map[string]*Source{
+			"foo": {
+				Alias:            "foo",
+				URL:              mustParseURL("http://foo.com"),
+				mimeTypeOverride: jsonMimetype,
+				timeout:          time.Second,
+				retries:          2,
+				auth:             &config.DSAuth{Token: "abcd"},
+				cacheTTL:         time.Minute,
+				optional:         true,
+				defaultValue:     "bar",
+			},
+		},
+	}
+	assert.EqualValues(t, expected, FromConfig(cfg))
+}
+
+func TestDatasourceOptions(t *testing.T) {
+	defer func(d time.Duration) { retryDelay = d }(retryDelay)
+	retryDelay = 0
+
+	calls := 0
+	failures := 0
+	d := &Data{
+		sourceReaders: map[string]func(*Source, ...string) ([]byte, error){
+			"test": func(s *Source, args ...string) ([]byte, error) {
+				calls++
+				if calls <= failures {
+					return nil, errors.New("transient failure")
+				}
+				return []byte(fmt.Sprintf(`{"calls": %d}`, calls)), nil
+			},
+		},
+		Sources: map[string]*Source{
+			"retry": {
+				Alias:            "retry",
+				URL:              mustParseURL("test:///retry"),
+				retries:          2,
+				mimeTypeOverride: jsonMimetype,
+			},
+			"noretry": {
+				Alias:            "noretry",
+				URL:              mustParseURL("test:///noretry"),
+				mimeTypeOverride: jsonMimetype,
+			},
+			"ttl": {
+				Alias:            "ttl",
+				URL:              mustParseURL("test:///ttl"),
+				mimeTypeOverride: jsonMimetype,
+				cacheTTL:         time.Nanosecond,
+			},
+			"optional": {
+				Alias:        "optional",
+				URL:          mustParseURL("bogus:///optional"),
+				optional:     true,
+				defaultValue: map[string]interface{}{"foo": "bar"},
+			},
+			"optionalText": {
+				Alias:        "optionalText",
+				URL:          mustParseURL("bogus:///optional"),
+				optional:     true,
+				defaultValue: "default text",
+			},
+		},
+	}
+
+	// 2 failures, then success on the last retry
+	failures = 2
+	out, err := d.Datasource("retry")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"calls": 3}, out)
+
+	calls, failures = 0, 1
+	_, err = d.Datasource("noretry")
+	assert.Error(t, err)
+
+	calls, failures = 0, 0
+	out, err = d.Datasource("ttl")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"calls": 1}, out)
+	time.Sleep(time.Millisecond)
+	out, err = d.Datasource("ttl")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"calls": 2}, out)
+
+	// without a TTL, the result stays cached
+	out, err = d.Datasource("retry")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"calls": 3}, out)
+
+	out, err = d.Datasource("optional")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, out)
+
+	s, err := d.Include("optionalText")
+	assert.NoError(t, err)
+	assert.Equal(t, "default text", s)
+
+	s, err = d.Include("optional")
+	assert.NoError(t, err)
+	assert.Equal(t, "", s)
 }
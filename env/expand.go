@@ -0,0 +1,166 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// Expand - replaces $VAR and ${VAR} in the string with the values of the
+// referenced environment variables, supporting the shell's ${VAR:-default},
+// ${VAR:=default}, ${VAR:?message}, and ${VAR:+alt} operators (and their
+// colon-less forms, which only check whether the variable is set, rather than
+// set and non-empty). Unlike in the shell, := doesn't assign the variable.
+//
+// Defaults and alternate values are themselves expanded. Use $$ for a literal $.
+// As with Getenv, `_FILE` variables are supported.
+func Expand(s string) (string, error) {
+	return expandVFS(afero.NewOsFs(), s, false)
+}
+
+// ExpandBraced - like Expand, but only ${VAR} expressions are expanded, and
+// $VAR is left as-is. Use $${ for a literal ${. This is useful for text that
+// may also contain template variables, such as config files.
+func ExpandBraced(s string) (string, error) {
+	return expandVFS(afero.NewOsFs(), s, true)
+}
+
+func expandVFS(fs afero.Fs, s string, braced bool) (string, error) {
+	out := &strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			out.WriteByte(s[i])
+			continue
+		}
+		switch c := s[i+1]; {
+		case braced && c == '$' && i+2 < len(s) && s[i+2] == '{':
+			out.WriteString("${")
+			i += 2
+		case braced && c != '{':
+			out.WriteByte('$')
+		case c == '$':
+			out.WriteByte('$')
+			i++
+		case c == '{':
+			end, err := closingBrace(s, i+2)
+			if err != nil {
+				return "", err
+			}
+			v, err := expandParam(fs, s[i+2:end], braced)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(v)
+			i = end
+		case isNameStart(c):
+			j := i + 2
+			for j < len(s) && isNameChar(s[j]) {
+				j++
+			}
+			v, _ := lookupEnvVFS(fs, s[i+1:j])
+			out.WriteString(v)
+			i = j - 1
+		default:
+			out.WriteByte('$')
+		}
+	}
+	return out.String(), nil
+}
+
+// closingBrace - find the index of the '}' closing the expression starting at
+// start, allowing for nested expressions in defaults
+func closingBrace(s string, start int) (int, error) {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch {
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '$':
+			i++
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '{':
+			depth++
+			i++
+		case s[i] == '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return -1, fmt.Errorf("unterminated expression ${%s", s[start:])
+}
+
+// expandParam - expand the contents of a ${...} expression
+func expandParam(fs afero.Fs, expr string, braced bool) (string, error) {
+	n := 0
+	for n < len(expr) && isNameChar(expr[n]) {
+		n++
+	}
+	name, op := expr[:n], expr[n:]
+	if name == "" || !isNameStart(name[0]) {
+		return "", fmt.Errorf("bad substitution ${%s}", expr)
+	}
+
+	val, ok := lookupEnvVFS(fs, name)
+	if op == "" {
+		return val, nil
+	}
+
+	colon := strings.HasPrefix(op, ":")
+	if colon {
+		op = op[1:]
+		// with a colon, empty values are treated as unset
+		ok = ok && val != ""
+	}
+	if op == "" {
+		return "", fmt.Errorf("bad substitution ${%s}", expr)
+	}
+	word := op[1:]
+
+	switch op[0] {
+	case '-', '=':
+		if ok {
+			return val, nil
+		}
+		return expandVFS(fs, word, braced)
+	case '+':
+		if !ok {
+			return "", nil
+		}
+		return expandVFS(fs, word, braced)
+	case '?':
+		if ok {
+			return val, nil
+		}
+		msg, err := expandVFS(fs, word, braced)
+		if err != nil {
+			return "", err
+		}
+		if msg == "" {
+			msg = "parameter null or not set"
+		}
+		return "", fmt.Errorf("%s: %s", name, msg)
+	default:
+		return "", fmt.Errorf("bad substitution ${%s}", expr)
+	}
+}
+
+// lookupEnvVFS - like getenvFile, but also reports whether the variable is set
+func lookupEnvVFS(fs afero.Fs, key string) (string, bool) {
+	if val, ok := os.LookupEnv(key); ok {
+		return val, true
+	}
+	if _, ok := os.LookupEnv(key + "_FILE"); ok {
+		val := getenvFile(fs, key)
+		return val, val != ""
+	}
+	return "", false
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || ('0' <= c && c <= '9')
+}
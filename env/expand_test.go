@@ -0,0 +1,101 @@
+package env
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpand(t *testing.T) {
+	os.Setenv("EXPAND_SET", "value")
+	os.Setenv("EXPAND_EMPTY", "")
+	defer os.Unsetenv("EXPAND_SET")
+	defer os.Unsetenv("EXPAND_EMPTY")
+
+	data := []struct {
+		in, out string
+	}{
+		{"", ""},
+		{"no vars", "no vars"},
+		{"$EXPAND_SET", "value"},
+		{"${EXPAND_SET}s", "values"},
+		{"$EXPAND_SET-$EXPAND_UNSET.", "value-."},
+		{"${EXPAND_UNSET:-default}", "default"},
+		{"${EXPAND_EMPTY:-default}", "default"},
+		{"${EXPAND_EMPTY-default}", ""},
+		{"${EXPAND_UNSET-default}", "default"},
+		{"${EXPAND_SET:-default}", "value"},
+		{"${EXPAND_UNSET:=default}", "default"},
+		{"${EXPAND_SET:+alt}", "alt"},
+		{"${EXPAND_EMPTY:+alt}", ""},
+		{"${EXPAND_EMPTY+alt}", "alt"},
+		{"${EXPAND_UNSET+alt}", ""},
+		{"${EXPAND_SET:?oops}", "value"},
+		{"${EXPAND_UNSET:-${EXPAND_SET}!}", "value!"},
+		{"${EXPAND_UNSET:-${EXPAND_ALSO_UNSET:-deep}}", "deep"},
+		{"${EXPAND_UNSET:-a }b}", "a b}"},
+		{"cost: $$5, $$EXPAND_SET", "cost: $5, $EXPAND_SET"},
+		{"$ and $1 and $", "$ and $1 and $"},
+	}
+	for _, d := range data {
+		out, err := Expand(d.in)
+		assert.NoError(t, err, d.in)
+		assert.Equal(t, d.out, out, d.in)
+	}
+
+	_, err := Expand("${EXPAND_UNSET:?must be set}")
+	assert.EqualError(t, err, "EXPAND_UNSET: must be set")
+
+	_, err = Expand("${EXPAND_EMPTY:?}")
+	assert.EqualError(t, err, "EXPAND_EMPTY: parameter null or not set")
+
+	_, err = Expand("${EXPAND_EMPTY?}")
+	assert.NoError(t, err)
+
+	for _, in := range []string{"${EXPAND_SET", "${}", "${1A}", "${EXPAND_SET:}", "${EXPAND_SET/a/b}", "${EXPAND_UNSET:-${EXPAND_UNSET:?nope}}"} {
+		_, err = Expand(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestExpandBraced(t *testing.T) {
+	os.Setenv("EXPAND_SET", "value")
+	defer os.Unsetenv("EXPAND_SET")
+
+	data := []struct {
+		in, out string
+	}{
+		{"$EXPAND_SET", "$EXPAND_SET"},
+		{"${EXPAND_SET}s", "values"},
+		{"{{ $x := 1 }}${EXPAND_SET}", "{{ $x := 1 }}value"},
+		{"${EXPAND_UNSET:-${EXPAND_SET}}", "value"},
+		{"${EXPAND_UNSET:-$EXPAND_SET}", "$EXPAND_SET"},
+		{"$$ and $${EXPAND_SET}", "$$ and ${EXPAND_SET}"},
+	}
+	for _, d := range data {
+		out, err := ExpandBraced(d.in)
+		assert.NoError(t, err, d.in)
+		assert.Equal(t, d.out, out, d.in)
+	}
+
+	_, err := ExpandBraced("${EXPAND_UNSET:?must be set}")
+	assert.EqualError(t, err, "EXPAND_UNSET: must be set")
+}
+
+func TestExpandFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, "/tmp/foo", []byte("foo\n"), 0644)
+
+	defer os.Unsetenv("FOO_FILE")
+	os.Setenv("FOO_FILE", "/tmp/foo")
+	out, err := expandVFS(fs, "foo is ${FOO:?missing}", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo is foo", out)
+
+	os.Setenv("FOO_FILE", "/tmp/missing")
+	out, err = expandVFS(fs, "${FOO:-default}", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "default", out)
+}
@@ -0,0 +1,148 @@
+package file
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// Entry - a file or directory found by Walk
+type Entry struct {
+	// Path is the path to the entry, including the walked root
+	Path string
+	// RelPath is the path to the entry, relative to the walked root, with
+	// forward slashes as separators
+	RelPath string
+	Name    string
+	Size    int64
+	Mode    os.FileMode
+	ModTime time.Time
+	IsDir   bool
+	// LinkTarget is the target of the symbolic link, if the entry is one
+	LinkTarget string
+}
+
+// WalkOptions - filters for Walk
+type WalkOptions struct {
+	// Glob, if set, only includes entries matching this pattern. Patterns
+	// containing a '/' are matched against the entry's relative path, others
+	// are matched against the entry's name.
+	Glob string
+	// Regexp, if set, only includes entries whose relative path matches
+	Regexp *regexp.Regexp
+	// MaxDepth limits how deep the walk descends - the root is at depth 0,
+	// and its direct children are at depth 1. Negative values mean no limit.
+	MaxDepth int
+	// Type, if set, only includes entries of the given type: "file", "dir",
+	// or "symlink"
+	Type string
+}
+
+// Walk the file tree rooted at root in lexical order, returning the entries
+// matched by the given options. Symbolic links are not followed.
+func Walk(fs afero.Fs, root string, opts WalkOptions) ([]Entry, error) {
+	switch opts.Type {
+	case "", "file", "dir", "symlink":
+	default:
+		return nil, errors.Errorf("invalid type %q: must be one of 'file', 'dir', or 'symlink'", opts.Type)
+	}
+	if opts.Glob != "" {
+		// validate the pattern up-front, rather than failing part-way through
+		if _, err := path.Match(opts.Glob, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid glob %q", opts.Glob)
+		}
+	}
+
+	entries := []Entry{}
+	err := afero.Walk(fs, root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		depth := 0
+		if rel != "." {
+			depth = strings.Count(rel, "/") + 1
+		}
+		if opts.MaxDepth >= 0 && depth > opts.MaxDepth {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !opts.matches(rel, fi) {
+			return nil
+		}
+
+		e := Entry{
+			Path:    p,
+			RelPath: rel,
+			Name:    fi.Name(),
+			Size:    fi.Size(),
+			Mode:    fi.Mode(),
+			ModTime: fi.ModTime(),
+			IsDir:   fi.IsDir(),
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			e.LinkTarget, err = readlink(fs, p)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read link %s", p)
+			}
+		}
+		entries = append(entries, e)
+		return nil
+	})
+	return entries, err
+}
+
+func (o WalkOptions) matches(rel string, fi os.FileInfo) bool {
+	switch o.Type {
+	case "file":
+		if fi.IsDir() || !fi.Mode().IsRegular() {
+			return false
+		}
+	case "dir":
+		if !fi.IsDir() {
+			return false
+		}
+	case "symlink":
+		if fi.Mode()&os.ModeSymlink == 0 {
+			return false
+		}
+	}
+
+	if o.Glob != "" {
+		name := fi.Name()
+		if strings.Contains(o.Glob, "/") {
+			name = rel
+		}
+		// the pattern was validated already, so errors can be ignored
+		if ok, _ := path.Match(o.Glob, name); !ok {
+			return false
+		}
+	}
+
+	if o.Regexp != nil && !o.Regexp.MatchString(rel) {
+		return false
+	}
+	return true
+}
+
+// readlink - only the OS filesystem supports symbolic links
+func readlink(fs afero.Fs, name string) (string, error) {
+	if _, ok := fs.(*afero.OsFs); ok {
+		return os.Readlink(name)
+	}
+	return "", nil
+}
@@ -0,0 +1,93 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	tfs "gotest.tools/v3/fs"
+)
+
+func relPaths(entries []Entry) []string {
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.RelPath
+	}
+	return out
+}
+
+func TestWalk(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_ = fs.MkdirAll("/tmp/a/b", 0777)
+	_ = fs.MkdirAll("/tmp/c", 0777)
+	_ = afero.WriteFile(fs, "/tmp/a/b/deep.txt", []byte("deep"), 0644)
+	_ = afero.WriteFile(fs, "/tmp/a/one.txt", []byte("one"), 0644)
+	_ = afero.WriteFile(fs, "/tmp/a/two.yaml", []byte("two"), 0600)
+	_ = afero.WriteFile(fs, "/tmp/top.txt", []byte("top!"), 0644)
+
+	entries, err := Walk(fs, "/tmp", WalkOptions{MaxDepth: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".", "a", "a/b", "a/b/deep.txt", "a/one.txt", "a/two.yaml", "c", "top.txt"}, relPaths(entries))
+
+	top := entries[len(entries)-1]
+	assert.Equal(t, filepath.Join("/tmp", "top.txt"), top.Path)
+	assert.Equal(t, "top.txt", top.Name)
+	assert.Equal(t, int64(4), top.Size)
+	assert.False(t, top.IsDir)
+	assert.Equal(t, "", top.LinkTarget)
+
+	entries, err = Walk(fs, "/tmp", WalkOptions{MaxDepth: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".", "a", "c", "top.txt"}, relPaths(entries))
+
+	entries, err = Walk(fs, "/tmp", WalkOptions{MaxDepth: 0})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"."}, relPaths(entries))
+
+	entries, err = Walk(fs, "/tmp", WalkOptions{MaxDepth: -1, Glob: "*.txt"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/b/deep.txt", "a/one.txt", "top.txt"}, relPaths(entries))
+
+	entries, err = Walk(fs, "/tmp", WalkOptions{MaxDepth: -1, Glob: "a/*"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/b", "a/one.txt", "a/two.yaml"}, relPaths(entries))
+
+	entries, err = Walk(fs, "/tmp", WalkOptions{MaxDepth: -1, Regexp: regexp.MustCompile(`^a/.*\.(txt|yaml)$`)})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/b/deep.txt", "a/one.txt", "a/two.yaml"}, relPaths(entries))
+
+	entries, err = Walk(fs, "/tmp", WalkOptions{MaxDepth: -1, Type: "dir"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".", "a", "a/b", "c"}, relPaths(entries))
+
+	entries, err = Walk(fs, "/tmp", WalkOptions{MaxDepth: 2, Type: "file"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/one.txt", "a/two.yaml", "top.txt"}, relPaths(entries))
+
+	_, err = Walk(fs, "/tmp", WalkOptions{Type: "socket"})
+	assert.Error(t, err)
+
+	_, err = Walk(fs, "/tmp", WalkOptions{Glob: "[a"})
+	assert.Error(t, err)
+
+	_, err = Walk(fs, "/nope", WalkOptions{MaxDepth: -1})
+	assert.Error(t, err)
+}
+
+func TestWalkSymlinks(t *testing.T) {
+	rootDir := tfs.NewDir(t, "gomplate-test",
+		tfs.WithFile("real.txt", "hello"),
+		tfs.WithSymlink("link.txt", "real.txt"),
+	)
+	defer rootDir.Remove()
+
+	entries, err := Walk(afero.NewOsFs(), rootDir.Path(), WalkOptions{MaxDepth: -1, Type: "symlink"})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "link.txt", entries[0].Name)
+	assert.Equal(t, rootDir.Join("real.txt"), entries[0].LinkTarget)
+	assert.NotZero(t, entries[0].Mode&os.ModeSymlink)
+}
@@ -29,5 +29,13 @@ func Funcs(d *data.Data) template.FuncMap {
 	funcs.AddCollFuncs(f)
 	funcs.AddUUIDFuncs(f)
 	funcs.AddRandomFuncs(f)
+	funcs.AddCompressFuncs(f)
+	funcs.AddArchiveFuncs(f)
+	funcs.AddURLFuncs(f)
+	funcs.AddHTMLFuncs(f)
+	funcs.AddMarkdownFuncs(f)
+	funcs.AddI18nFuncs(f, d)
+	funcs.AddGCPFuncs(f)
+	funcs.AddAzureFuncs(f)
 	return f
 }
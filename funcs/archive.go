@@ -0,0 +1,38 @@
+package funcs
+
+import (
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v3/archive"
+	"github.com/hairyhenderson/gomplate/v3/conv"
+)
+
+var (
+	archiveNS     *ArchiveFuncs
+	archiveNSInit sync.Once
+)
+
+// ArchiveNS - the archive namespace
+func ArchiveNS() *ArchiveFuncs {
+	archiveNSInit.Do(func() { archiveNS = &ArchiveFuncs{} })
+	return archiveNS
+}
+
+// AddArchiveFuncs -
+func AddArchiveFuncs(f map[string]interface{}) {
+	f["archive"] = ArchiveNS
+}
+
+// ArchiveFuncs -
+type ArchiveFuncs struct{}
+
+// List -
+func (f *ArchiveFuncs) List(in interface{}) ([]string, error) {
+	return archive.List(toBytes(in))
+}
+
+// Read -
+func (f *ArchiveFuncs) Read(name, in interface{}) (string, error) {
+	out, err := archive.Read(toBytes(in), conv.ToString(name))
+	return string(out), err
+}
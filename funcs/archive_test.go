@@ -0,0 +1,31 @@
+package funcs
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveFuncs(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	fw, _ := w.Create("dir/hello.txt")
+	_, _ = fw.Write([]byte("hello world"))
+	_ = w.Close()
+
+	f := ArchiveNS()
+
+	// archives read with include are strings
+	names, err := f.List(buf.String())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dir/hello.txt"}, names)
+
+	out, err := f.Read("dir/hello.txt", buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", out)
+
+	_, err = f.Read("nope.txt", buf.Bytes())
+	assert.Error(t, err)
+}
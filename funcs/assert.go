@@ -0,0 +1,90 @@
+package funcs
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/hairyhenderson/gomplate/v3/conv"
+	iconv "github.com/hairyhenderson/gomplate/v3/internal/conv"
+	"github.com/hairyhenderson/gomplate/v3/test"
+)
+
+var (
+	assertNS     *AssertFuncs
+	assertNSInit sync.Once
+)
+
+// AssertNS - the assert namespace
+func AssertNS() *AssertFuncs {
+	assertNSInit.Do(func() { assertNS = &AssertFuncs{} })
+	return assertNS
+}
+
+// assertFunc - returns the assert namespace when called with no arguments, so
+// that the `assert` alias for test.Assert keeps working
+func assertFunc(args ...interface{}) (interface{}, error) {
+	if len(args) == 0 {
+		return AssertNS(), nil
+	}
+	return TestNS().Assert(args...)
+}
+
+// AssertFuncs -
+type AssertFuncs struct{}
+
+// Equal -
+func (f *AssertFuncs) Equal(args ...interface{}) (string, error) {
+	message, args, err := assertArgs(args)
+	if err != nil {
+		return "", err
+	}
+	return test.AssertEqual(message, args[0], args[1])
+}
+
+// Type -
+func (f *AssertFuncs) Type(args ...interface{}) (string, error) {
+	message, args, err := assertArgs(args)
+	if err != nil {
+		return "", err
+	}
+	return test.AssertType(message, conv.ToString(args[0]), args[1])
+}
+
+// Matches -
+func (f *AssertFuncs) Matches(args ...interface{}) (string, error) {
+	message, args, err := assertArgs(args)
+	if err != nil {
+		return "", err
+	}
+	return test.AssertMatches(message, conv.ToString(args[0]), conv.ToString(args[1]))
+}
+
+// OneOf -
+func (f *AssertFuncs) OneOf(args ...interface{}) (string, error) {
+	message, args, err := assertArgs(args)
+	if err != nil {
+		return "", err
+	}
+	allowed, err := iconv.InterfaceSlice(args[0])
+	if err != nil {
+		return "", errors.Errorf("expected a list of allowed values, got %T", args[0])
+	}
+	return test.AssertOneOf(message, allowed, args[1])
+}
+
+// assertArgs - split off the optional leading message from the two operands
+func assertArgs(args []interface{}) (string, []interface{}, error) {
+	switch len(args) {
+	case 2:
+		return "", args, nil
+	case 3:
+		message, ok := args[0].(string)
+		if !ok {
+			return "", nil, errors.Errorf("at <1>: expected string; found %T", args[0])
+		}
+		return message, args[1:], nil
+	default:
+		return "", nil, errors.Errorf("wrong number of args: want 2 or 3, got %d", len(args))
+	}
+}
@@ -0,0 +1,63 @@
+package funcs
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertNSIsIdempotent(t *testing.T) {
+	left := AssertNS()
+	right := AssertNS()
+	assert.True(t, left == right)
+}
+
+func TestAssertFunc(t *testing.T) {
+	ns, err := assertFunc()
+	assert.NoError(t, err)
+	assert.Equal(t, AssertNS(), ns)
+
+	_, err = assertFunc(true)
+	assert.NoError(t, err)
+	_, err = assertFunc("oops", false)
+	assert.EqualError(t, err, "assertion failed: oops")
+
+	// both forms work in a template
+	tmpl := template.Must(template.New("t").Funcs(template.FuncMap{"assert": assertFunc}).
+		Parse(`{{ assert true }}{{ assert.Equal 1 1 }}{{ assert.Equal "bad port" 8080 . }}`))
+	err = tmpl.Execute(&bytes.Buffer{}, 80)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `template: t:1:`)
+	assert.Contains(t, err.Error(), `error calling Equal: assertion failed: bad port (expected 8080, got 80)`)
+}
+
+func TestAssertFuncs(t *testing.T) {
+	a := AssertNS()
+	_, err := a.Equal("foo", "foo")
+	assert.NoError(t, err)
+	_, err = a.Equal("msg", "foo", "bar")
+	assert.EqualError(t, err, `assertion failed: msg (expected "foo", got "bar")`)
+	_, err = a.Equal(42, "foo", "bar")
+	assert.Error(t, err)
+	_, err = a.Equal("foo")
+	assert.Error(t, err)
+
+	_, err = a.Type("map", map[string]interface{}{})
+	assert.NoError(t, err)
+	_, err = a.Type("string", 42)
+	assert.Error(t, err)
+
+	_, err = a.Matches(`^\d+$`, 42)
+	assert.NoError(t, err)
+	_, err = a.Matches("must be numeric", `^\d+$`, "4x2")
+	assert.Error(t, err)
+
+	_, err = a.OneOf([]string{"dev", "prod"}, "dev")
+	assert.NoError(t, err)
+	_, err = a.OneOf([]interface{}{"dev", "prod"}, "qa")
+	assert.Error(t, err)
+	_, err = a.OneOf("dev", "dev")
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hairyhenderson/gomplate/v3/aws"
 	"github.com/hairyhenderson/gomplate/v3/conv"
+	"github.com/pkg/errors"
 )
 
 var (
@@ -39,10 +40,12 @@ type Funcs struct {
 	info     *aws.Ec2Info
 	kms      *aws.KMS
 	sts      *aws.STS
+	ec2      *aws.EC2
 	metaInit sync.Once
 	infoInit sync.Once
 	kmsInit  sync.Once
 	stsInit  sync.Once
+	ec2Init  sync.Once
 	awsopts  aws.ClientOptions
 }
 
@@ -70,6 +73,40 @@ func (a *Funcs) EC2Tag(tag string, def ...string) (string, error) {
 	return a.info.Tag(tag, def...)
 }
 
+// Regions -
+func (a *Funcs) Regions() ([]string, error) {
+	a.ec2Init.Do(a.initEC2)
+	return a.ec2.Regions()
+}
+
+// AZs -
+func (a *Funcs) AZs(region ...string) ([]string, error) {
+	if len(region) > 1 {
+		return nil, errors.Errorf("wrong number of args: wanted 0 or 1, got %d", len(region))
+	}
+	r := ""
+	if len(region) == 1 {
+		r = region[0]
+	}
+	a.ec2Init.Do(a.initEC2)
+	return a.ec2.AZs(r)
+}
+
+// EC2Instances -
+func (a *Funcs) EC2Instances(filters ...map[string]interface{}) ([]map[string]interface{}, error) {
+	if len(filters) > 1 {
+		return nil, errors.Errorf("wrong number of args: wanted 0 or 1, got %d", len(filters))
+	}
+	f := map[string][]string{}
+	for _, m := range filters {
+		for k, v := range m {
+			f[k] = toStringList(v)
+		}
+	}
+	a.ec2Init.Do(a.initEC2)
+	return a.ec2.Instances(f)
+}
+
 // KMSEncrypt -
 func (a *Funcs) KMSEncrypt(keyID, plaintext interface{}) (string, error) {
 	a.kmsInit.Do(a.initKMS)
@@ -99,6 +136,18 @@ func (a *Funcs) Account() (string, error) {
 	return a.sts.Account()
 }
 
+// AccountID - Gets the AWS account ID number. In EC2, this is the ID of the
+// account that owns the instance (read from the instance identity document),
+// and elsewhere it's the same as Account.
+func (a *Funcs) AccountID() (string, error) {
+	a.metaInit.Do(a.initMeta)
+	id, err := a.meta.AccountID()
+	if err != nil || id != "" {
+		return id, err
+	}
+	return a.Account()
+}
+
 // ARN - Gets the AWS ARN associated with the calling entity
 func (a *Funcs) ARN() (string, error) {
 	a.stsInit.Do(a.initSTS)
@@ -123,6 +172,12 @@ func (a *Funcs) initKMS() {
 	}
 }
 
+func (a *Funcs) initEC2() {
+	if a.ec2 == nil {
+		a.ec2 = aws.NewEC2(a.awsopts)
+	}
+}
+
 func (a *Funcs) initSTS() {
 	if a.sts == nil {
 		a.sts = aws.NewSTS(a.awsopts)
@@ -0,0 +1,104 @@
+package funcs
+
+import (
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v3/azure"
+)
+
+var (
+	azureNS     *AzureFuncs
+	azureNSInit sync.Once
+)
+
+// AzureNS - the azure namespace
+func AzureNS() *AzureFuncs {
+	azureNSInit.Do(func() {
+		azureNS = &AzureFuncs{
+			azopts: azure.GetClientOptions(),
+		}
+	})
+	return azureNS
+}
+
+// AddAzureFuncs -
+func AddAzureFuncs(f map[string]interface{}) {
+	f["azure"] = AzureNS
+}
+
+// AzureFuncs -
+type AzureFuncs struct {
+	meta     *azure.Meta
+	metaInit sync.Once
+	azopts   azure.ClientOptions
+}
+
+// Meta -
+func (a *AzureFuncs) Meta(path string, def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Meta(path, def...)
+}
+
+// VMName -
+func (a *AzureFuncs) VMName(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.VMName(def...)
+}
+
+// VMID -
+func (a *AzureFuncs) VMID(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.VMID(def...)
+}
+
+// VMSize -
+func (a *AzureFuncs) VMSize(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.VMSize(def...)
+}
+
+// Location -
+func (a *AzureFuncs) Location(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Location(def...)
+}
+
+// ResourceGroup -
+func (a *AzureFuncs) ResourceGroup(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.ResourceGroup(def...)
+}
+
+// SubscriptionID -
+func (a *AzureFuncs) SubscriptionID(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.SubscriptionID(def...)
+}
+
+// Tags -
+func (a *AzureFuncs) Tags() (map[string]string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Tags()
+}
+
+// Tag -
+func (a *AzureFuncs) Tag(name string, def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Tag(name, def...)
+}
+
+// Token -
+func (a *AzureFuncs) Token(resource ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	r := ""
+	if len(resource) > 0 {
+		r = resource[0]
+	}
+	return a.meta.Token(r)
+}
+
+func (a *AzureFuncs) initMeta() {
+	if a.meta == nil {
+		a.meta = azure.NewMeta(a.azopts)
+	}
+}
@@ -0,0 +1,28 @@
+package funcs
+
+import (
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v3/azure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAzureNSIsIdempotent(t *testing.T) {
+	left := AzureNS()
+	right := AzureNS()
+	assert.True(t, left == right)
+}
+
+func TestAzureFuncs(t *testing.T) {
+	// nothing listens on this port, so this behaves as if not in Azure
+	m := azure.NewMeta(azure.ClientOptions{})
+	m.Endpoint = "http://127.0.0.1:1"
+	a := &AzureFuncs{meta: m}
+	assert.Equal(t, "default", must(a.VMName("default")))
+	assert.Equal(t, "", must(a.ResourceGroup()))
+	assert.Equal(t, "eastus", must(a.Location("eastus")))
+	assert.Equal(t, "none", must(a.Tag("env", "none")))
+	assert.Equal(t, map[string]string{}, must(a.Tags()))
+	_, err := a.Token()
+	assert.Error(t, err)
+}
@@ -37,6 +37,8 @@ func AddCollFuncs(f map[string]interface{}) {
 	f["sort"] = CollNS().Sort
 	f["jsonpath"] = CollNS().JSONPath
 	f["flatten"] = CollNS().Flatten
+	f["pickPath"] = CollNS().PickPath
+	f["omitPath"] = CollNS().OmitPath
 }
 
 // CollFuncs -
@@ -94,21 +96,12 @@ func (f *CollFuncs) Merge(dst map[string]interface{}, src ...map[string]interfac
 
 // Sort -
 func (f *CollFuncs) Sort(args ...interface{}) ([]interface{}, error) {
-	var (
-		key  string
-		list interface{}
-	)
-	if len(args) == 0 || len(args) > 2 {
-		return nil, errors.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
-	}
-	if len(args) == 1 {
-		list = args[0]
-	}
-	if len(args) == 2 {
-		key = conv.ToString(args[0])
-		list = args[1]
+	if len(args) == 0 {
+		return nil, errors.Errorf("wrong number of args: wanted at least 1, got %d", len(args))
 	}
-	return coll.Sort(key, list)
+	list := args[len(args)-1]
+	keys := conv.ToStrings(args[:len(args)-1]...)
+	return coll.SortBy(list, keys...)
 }
 
 // JSONPath -
@@ -129,3 +122,23 @@ func (f *CollFuncs) Flatten(args ...interface{}) ([]interface{}, error) {
 	}
 	return coll.Flatten(list, depth)
 }
+
+// PickPath -
+func (f *CollFuncs) PickPath(args ...interface{}) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, errors.Errorf("wrong number of args: wanted at least 2, got %d", len(args))
+	}
+	in := args[len(args)-1]
+	paths := conv.ToStrings(args[:len(args)-1]...)
+	return coll.PickPath(in, paths...)
+}
+
+// OmitPath -
+func (f *CollFuncs) OmitPath(args ...interface{}) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, errors.Errorf("wrong number of args: wanted at least 2, got %d", len(args))
+	}
+	in := args[len(args)-1]
+	paths := conv.ToStrings(args[:len(args)-1]...)
+	return coll.OmitPath(in, paths...)
+}
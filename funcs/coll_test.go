@@ -23,3 +23,46 @@ func TestFlatten(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, []interface{}{1, []int{2}, 3}, out)
 }
+
+func TestCollSort(t *testing.T) {
+	c := CollNS()
+
+	_, err := c.Sort()
+	assert.Error(t, err)
+
+	out, err := c.Sort([]string{"b", "a"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{"a", "b"}, out)
+
+	in := []map[string]interface{}{
+		{"a": "x", "b": 2},
+		{"a": "y", "b": 1},
+		{"a": "x", "b": 1},
+	}
+	out, err = c.Sort("a", "-b", in)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{
+		map[string]interface{}{"a": "x", "b": 2},
+		map[string]interface{}{"a": "x", "b": 1},
+		map[string]interface{}{"a": "y", "b": 1},
+	}, out)
+}
+
+func TestPickOmitPath(t *testing.T) {
+	c := CollNS()
+
+	_, err := c.PickPath(map[string]interface{}{})
+	assert.Error(t, err)
+	_, err = c.OmitPath(map[string]interface{}{})
+	assert.Error(t, err)
+
+	in := map[string]interface{}{"a": 1, "b": map[string]interface{}{"c": 2, "d": 3}}
+
+	out, err := c.PickPath("a", "b.c", in)
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"a": 1, "b": map[string]interface{}{"c": 2}}, out)
+
+	out, err = c.OmitPath("a", "b.c", in)
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"b": map[string]interface{}{"d": 3}}, out)
+}
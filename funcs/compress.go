@@ -0,0 +1,117 @@
+package funcs
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v3/base64"
+	"github.com/hairyhenderson/gomplate/v3/compress"
+	"github.com/hairyhenderson/gomplate/v3/conv"
+	"github.com/pkg/errors"
+)
+
+var (
+	compressNS     *CompressFuncs
+	compressNSInit sync.Once
+)
+
+// CompressNS - the compress namespace
+func CompressNS() *CompressFuncs {
+	compressNSInit.Do(func() { compressNS = &CompressFuncs{} })
+	return compressNS
+}
+
+// AddCompressFuncs -
+func AddCompressFuncs(f map[string]interface{}) {
+	f["compress"] = CompressNS
+}
+
+// CompressFuncs -
+type CompressFuncs struct{}
+
+// Gzip -
+func (f *CompressFuncs) Gzip(args ...interface{}) ([]byte, error) {
+	level, in, err := compressArgs(-1, args)
+	if err != nil {
+		return nil, err
+	}
+	return compress.Gzip(in, level)
+}
+
+// Gunzip -
+func (f *CompressFuncs) Gunzip(in interface{}) (string, error) {
+	b, err := compressedInput(in, compress.GzipMagic)
+	if err != nil {
+		return "", err
+	}
+	out, err := compress.Gunzip(b)
+	return string(out), err
+}
+
+// Zstd -
+func (f *CompressFuncs) Zstd(args ...interface{}) ([]byte, error) {
+	level, in, err := compressArgs(0, args)
+	if err != nil {
+		return nil, err
+	}
+	return compress.Zstd(in, level)
+}
+
+// Unzstd -
+func (f *CompressFuncs) Unzstd(in interface{}) (string, error) {
+	b, err := compressedInput(in, compress.ZstdMagic)
+	if err != nil {
+		return "", err
+	}
+	out, err := compress.Unzstd(b)
+	return string(out), err
+}
+
+// Brotli -
+func (f *CompressFuncs) Brotli(args ...interface{}) ([]byte, error) {
+	level, in, err := compressArgs(-1, args)
+	if err != nil {
+		return nil, err
+	}
+	return compress.Brotli(in, level)
+}
+
+// Unbrotli -
+func (f *CompressFuncs) Unbrotli(in interface{}) (string, error) {
+	b, err := compressedInput(in, nil)
+	if err != nil {
+		return "", err
+	}
+	out, err := compress.Unbrotli(b)
+	return string(out), err
+}
+
+// compressArgs - parse the optional level and the input
+func compressArgs(defLevel int, args []interface{}) (level int, in []byte, err error) {
+	switch len(args) {
+	case 1:
+		return defLevel, toBytes(args[0]), nil
+	case 2:
+		return conv.ToInt(args[0]), toBytes(args[1]), nil
+	default:
+		return 0, nil, errors.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+	}
+}
+
+// compressedInput - byte arrays are used as-is, as are strings starting with
+// the format's magic number (i.e. raw compressed data read from a file), but
+// other strings are expected to be base64-encoded.
+func compressedInput(in interface{}, magic []byte) ([]byte, error) {
+	if b, ok := in.([]byte); ok {
+		return b, nil
+	}
+	b := toBytes(in)
+	if magic != nil && bytes.HasPrefix(b, magic) {
+		return b, nil
+	}
+	out, err := base64.Decode(string(b))
+	if err != nil {
+		return nil, errors.Wrap(err, "compressed input must be a byte array or a base64-encoded string")
+	}
+	return out, nil
+}
@@ -0,0 +1,56 @@
+package funcs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v3/base64"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressRoundTrip(t *testing.T) {
+	f := CompressNS()
+	in := strings.Repeat("hello ", 50)
+
+	data := []struct {
+		c func(...interface{}) ([]byte, error)
+		d func(interface{}) (string, error)
+	}{
+		{f.Gzip, f.Gunzip},
+		{f.Zstd, f.Unzstd},
+		{f.Brotli, f.Unbrotli},
+	}
+	for _, d := range data {
+		out, err := d.c(in)
+		assert.NoError(t, err)
+
+		// raw bytes
+		s, err := d.d(out)
+		assert.NoError(t, err)
+		assert.Equal(t, in, s)
+
+		// base64-encoded
+		enc, _ := base64.Encode(out)
+		s, err = d.d(enc)
+		assert.NoError(t, err)
+		assert.Equal(t, in, s)
+
+		out, err = d.c("1", in)
+		assert.NoError(t, err)
+		s, err = d.d(out)
+		assert.NoError(t, err)
+		assert.Equal(t, in, s)
+
+		_, err = d.c()
+		assert.Error(t, err)
+
+		_, err = d.d("not base64!")
+		assert.Error(t, err)
+	}
+
+	// raw compressed strings (e.g. from file.Read) are detected by their magic numbers
+	out, _ := f.Gzip(in)
+	s, err := f.Gunzip(string(out))
+	assert.NoError(t, err)
+	assert.Equal(t, in, s)
+}
@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/hairyhenderson/gomplate/v3/coll"
 	"github.com/hairyhenderson/gomplate/v3/conv"
@@ -140,3 +141,48 @@ func (f *ConvFuncs) Default(def, in interface{}) interface{} {
 func (f *ConvFuncs) Dict(in ...interface{}) (map[string]interface{}, error) {
 	return coll.Dict(in...)
 }
+
+// ParseSize -
+func (f *ConvFuncs) ParseSize(in interface{}) (int64, error) {
+	return conv.ParseSize(conv.ToString(in))
+}
+
+// HumanSize - size strings like "10GB" are parsed first, and other values are
+// treated as a number of bytes
+func (f *ConvFuncs) HumanSize(in interface{}) (string, error) {
+	if s, ok := in.(string); ok {
+		n, err := conv.ParseSize(s)
+		if err != nil {
+			return "", err
+		}
+		return conv.HumanSize(n), nil
+	}
+	return conv.HumanSize(conv.ToInt64(in)), nil
+}
+
+// ParseDuration -
+func (f *ConvFuncs) ParseDuration(in interface{}) (time.Duration, error) {
+	if d, ok := in.(time.Duration); ok {
+		return d, nil
+	}
+	return conv.ParseDuration(conv.ToString(in))
+}
+
+// HumanDuration - duration strings like "36h" are parsed first, and other
+// numbers are treated as a number of seconds
+func (f *ConvFuncs) HumanDuration(in interface{}) (string, error) {
+	var d time.Duration
+	switch v := in.(type) {
+	case time.Duration:
+		d = v
+	case string:
+		var err error
+		d, err = conv.ParseDuration(v)
+		if err != nil {
+			return "", err
+		}
+	default:
+		d = time.Duration(conv.ToFloat64(in) * float64(time.Second))
+	}
+	return conv.HumanDuration(d), nil
+}
@@ -3,6 +3,7 @@ package funcs
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -37,3 +38,32 @@ func TestDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestSizeFuncs(t *testing.T) {
+	c := ConvNS()
+	assert.Equal(t, int64(10737418240), must(c.ParseSize("10GiB")))
+	assert.Equal(t, int64(512), must(c.ParseSize(512)))
+	_, err := c.ParseSize("10 furlongs")
+	assert.Error(t, err)
+
+	assert.Equal(t, "117.74MiB", must(c.HumanSize(123456789)))
+	assert.Equal(t, "117.74MiB", must(c.HumanSize("123456789")))
+	assert.Equal(t, "9.31GiB", must(c.HumanSize("10GB")))
+	_, err = c.HumanSize("lots")
+	assert.Error(t, err)
+}
+
+func TestDurationFuncs(t *testing.T) {
+	c := ConvNS()
+	assert.Equal(t, 36*time.Hour, must(c.ParseDuration("1d12h")))
+	assert.Equal(t, time.Minute, must(c.ParseDuration(time.Minute)))
+	_, err := c.ParseDuration("soon")
+	assert.Error(t, err)
+
+	assert.Equal(t, "1d12h", must(c.HumanDuration("36h")))
+	assert.Equal(t, "1h1m", must(c.HumanDuration(3660)))
+	assert.Equal(t, "1.5s", must(c.HumanDuration(1.5)))
+	assert.Equal(t, "2h", must(c.HumanDuration(2*time.Hour)))
+	_, err = c.HumanDuration("soon")
+	assert.Error(t, err)
+}
@@ -6,9 +6,15 @@ import (
 	"crypto/sha256"
 	"crypto/sha512"
 	"fmt"
+	"hash/crc32"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/cespare/xxhash/v2"
+	"github.com/zeebo/blake3"
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/blake2b"
 
 	"github.com/hairyhenderson/gomplate/v3/conv"
 	"github.com/pkg/errors"
@@ -114,6 +120,50 @@ func (f *CryptoFuncs) SHA512_256(input interface{}) string {
 	return fmt.Sprintf("%02x", out)
 }
 
+// BLAKE2b_256 -
+// nolint: golint stylecheck
+func (f *CryptoFuncs) BLAKE2b_256(input interface{}) string {
+	in := toBytes(input)
+	out := blake2b.Sum256(in)
+	return fmt.Sprintf("%02x", out)
+}
+
+// BLAKE2b_384 -
+// nolint: golint stylecheck
+func (f *CryptoFuncs) BLAKE2b_384(input interface{}) string {
+	in := toBytes(input)
+	out := blake2b.Sum384(in)
+	return fmt.Sprintf("%02x", out)
+}
+
+// BLAKE2b_512 -
+// nolint: golint stylecheck
+func (f *CryptoFuncs) BLAKE2b_512(input interface{}) string {
+	in := toBytes(input)
+	out := blake2b.Sum512(in)
+	return fmt.Sprintf("%02x", out)
+}
+
+// BLAKE3 -
+func (f *CryptoFuncs) BLAKE3(input interface{}) string {
+	in := toBytes(input)
+	out := blake3.Sum256(in)
+	return fmt.Sprintf("%02x", out)
+}
+
+// XXHash64 - Note: xxHash is not a cryptographic hash function, and should not be used for secure applications.
+func (f *CryptoFuncs) XXHash64(input interface{}) string {
+	in := toBytes(input)
+	return fmt.Sprintf("%016x", xxhash.Sum64(in))
+}
+
+// CRC32C - the CRC-32 checksum, using the Castagnoli polynomial. Note: this is
+// not a cryptographic hash function, and should not be used for secure applications.
+func (f *CryptoFuncs) CRC32C(input interface{}) string {
+	in := toBytes(input)
+	return fmt.Sprintf("%08x", crc32.Checksum(in, crc32.MakeTable(crc32.Castagnoli)))
+}
+
 // Bcrypt -
 func (f *CryptoFuncs) Bcrypt(args ...interface{}) (string, error) {
 	input := ""
@@ -128,6 +178,155 @@ func (f *CryptoFuncs) Bcrypt(args ...interface{}) (string, error) {
 		cost = conv.ToInt(args[0])
 		input = conv.ToString(args[1])
 	}
+	if len(args) > 2 {
+		return "", errors.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+	}
 	hash, err := bcrypt.GenerateFromPassword([]byte(input), cost)
 	return string(hash), err
 }
+
+// Htpasswd - generate an htpasswd-format line for the given user, with the
+// password hashed with bcrypt
+func (f *CryptoFuncs) Htpasswd(args ...interface{}) (string, error) {
+	cost := bcrypt.DefaultCost
+	switch len(args) {
+	case 2:
+	case 3:
+		cost = conv.ToInt(args[0])
+		args = args[1:]
+	default:
+		return "", errors.Errorf("wrong number of args: wanted 2 or 3, got %d", len(args))
+	}
+	user := conv.ToString(args[0])
+	if user == "" || strings.Contains(user, ":") {
+		return "", errors.Errorf("invalid htpasswd user %q: must be non-empty and must not contain ':'", user)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(conv.ToString(args[1])), cost)
+	if err != nil {
+		return "", err
+	}
+	// Apache's htpasswd tool uses the $2y$ prefix, which is otherwise
+	// identical to Go's $2a$
+	return user + ":$2y$" + strings.TrimPrefix(string(hash), "$2a$"), nil
+}
+
+// SSHFingerprint -
+func (f *CryptoFuncs) SSHFingerprint(args ...interface{}) (string, error) {
+	switch len(args) {
+	case 1:
+		return crypto.SSHFingerprint("", toBytes(args[0]))
+	case 2:
+		return crypto.SSHFingerprint(conv.ToString(args[0]), toBytes(args[1]))
+	default:
+		return "", errors.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+	}
+}
+
+// ParseAuthorizedKeys -
+func (f *CryptoFuncs) ParseAuthorizedKeys(in interface{}) ([]crypto.AuthorizedKey, error) {
+	return crypto.ParseAuthorizedKeys(toBytes(in))
+}
+
+// ToAuthorizedKey -
+func (f *CryptoFuncs) ToAuthorizedKey(args ...interface{}) (string, error) {
+	switch len(args) {
+	case 1:
+		return crypto.ToAuthorizedKey(toBytes(args[0]), "")
+	case 2:
+		return crypto.ToAuthorizedKey(toBytes(args[1]), conv.ToString(args[0]))
+	default:
+		return "", errors.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+	}
+}
+
+// TOTP -
+func (f *CryptoFuncs) TOTP(args ...interface{}) (string, error) {
+	var m map[string]interface{}
+	switch len(args) {
+	case 1:
+	case 2:
+		var ok bool
+		m, ok = args[0].(map[string]interface{})
+		if !ok {
+			return "", errors.Errorf("expected an options map as the first argument, got %T", args[0])
+		}
+	default:
+		return "", errors.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+	}
+	opts, now, err := otpOpts(m, true)
+	if err != nil {
+		return "", err
+	}
+	key, err := crypto.DecodeOTPSecret(conv.ToString(args[len(args)-1]))
+	if err != nil {
+		return "", err
+	}
+	return crypto.TOTP(key, now, opts)
+}
+
+// HOTP -
+func (f *CryptoFuncs) HOTP(args ...interface{}) (string, error) {
+	var m map[string]interface{}
+	switch len(args) {
+	case 2:
+	case 3:
+		var ok bool
+		m, ok = args[0].(map[string]interface{})
+		if !ok {
+			return "", errors.Errorf("expected an options map as the first argument, got %T", args[0])
+		}
+		args = args[1:]
+	default:
+		return "", errors.Errorf("wrong number of args: wanted 2 or 3, got %d", len(args))
+	}
+	opts, _, err := otpOpts(m, false)
+	if err != nil {
+		return "", err
+	}
+	counter := conv.ToInt64(args[0])
+	if counter < 0 {
+		return "", errors.Errorf("invalid HOTP counter %d: must not be negative", counter)
+	}
+	key, err := crypto.DecodeOTPSecret(conv.ToString(args[1]))
+	if err != nil {
+		return "", err
+	}
+	return crypto.HOTP(key, uint64(counter), opts)
+}
+
+// otpOpts - parse the OTP options map. For TOTP, the "period" (in seconds,
+// or as a duration string) and "time" (a time, or Unix seconds) options are
+// also accepted.
+func otpOpts(m map[string]interface{}, totp bool) (opts crypto.OTPOpts, now time.Time, err error) {
+	now = time.Now()
+	for k, v := range m {
+		switch {
+		case k == "digits":
+			opts.Digits = conv.ToInt(v)
+		case k == "algorithm":
+			opts.Algorithm = conv.ToString(v)
+		case k == "period" && totp:
+			if s, ok := v.(string); ok && !isNumeric(s) {
+				opts.Period, err = time.ParseDuration(s)
+				if err != nil {
+					return opts, now, err
+				}
+			} else {
+				opts.Period = time.Duration(conv.ToInt64(v)) * time.Second
+			}
+		case k == "time" && totp:
+			if t, ok := v.(time.Time); ok {
+				now = t
+			} else {
+				now = time.Unix(conv.ToInt64(v), 0)
+			}
+		default:
+			return opts, now, errors.Errorf("unknown option %q", k)
+		}
+	}
+	return opts, now, nil
+}
+
+func isNumeric(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
@@ -3,8 +3,10 @@ package funcs
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestPBKDF2(t *testing.T) {
@@ -46,6 +48,18 @@ func TestSHA(t *testing.T) {
 	assert.Equal(t, sha512_256, c.SHA512_256(in))
 }
 
+func TestChecksums(t *testing.T) {
+	in := "abc"
+	c := CryptoNS()
+	assert.Equal(t, "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319", c.BLAKE2b_256(in))
+	assert.Equal(t, "6f56a82c8e7ef526dfe182eb5212f7db9df1317e57815dbda46083fc30f54ee6c66ba83be64b302d7cba6ce15bb556f4", c.BLAKE2b_384(in))
+	assert.Equal(t, "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923", c.BLAKE2b_512(in))
+	assert.Equal(t, "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", c.BLAKE3(in))
+	assert.Equal(t, "44bc2cf5ad770999", c.XXHash64(in))
+	assert.Equal(t, "364b3fb7", c.CRC32C(in))
+	assert.Equal(t, "00000000", c.CRC32C(""))
+}
+
 func TestBcrypt(t *testing.T) {
 	in := "foo"
 	c := CryptoNS()
@@ -63,4 +77,86 @@ func TestBcrypt(t *testing.T) {
 
 	_, err = c.Bcrypt()
 	assert.Error(t, err)
+
+	_, err = c.Bcrypt(4, in, "extra")
+	assert.Error(t, err)
+}
+
+func TestHtpasswd(t *testing.T) {
+	c := CryptoNS()
+	actual, err := c.Htpasswd("admin", "secret")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(actual, "admin:$2y$10$"))
+	hash := strings.TrimPrefix(actual, "admin:")
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(hash), []byte("secret")))
+
+	actual, err = c.Htpasswd(5, "admin", "secret")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(actual, "admin:$2y$05$"))
+
+	_, err = c.Htpasswd("admin")
+	assert.Error(t, err)
+
+	_, err = c.Htpasswd("ad:min", "secret")
+	assert.Error(t, err)
+
+	_, err = c.Htpasswd("", "secret")
+	assert.Error(t, err)
+
+	_, err = c.Htpasswd(99, "admin", "secret")
+	assert.Error(t, err)
+}
+
+func TestSSHKeys(t *testing.T) {
+	c := CryptoNS()
+	pub := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGtZHdsWNHqkasMVnuyUGz/DrClhCfZEWilwYtBO3PRq alice@example.com"
+
+	assert.Equal(t, "SHA256:xiFoCwPw4RpRmHW62/4Q+qRcxCx0r6+pdHYJl8n7eQA", must(c.SSHFingerprint(pub)))
+	assert.Equal(t, "MD5:50:4d:53:75:80:1d:af:2f:fe:1e:fc:d6:d2:d9:22:53", must(c.SSHFingerprint("md5", pub)))
+	_, err := c.SSHFingerprint()
+	assert.Error(t, err)
+
+	keys, err := c.ParseAuthorizedKeys("# comment\n" + pub + "\n")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, "alice@example.com", keys[0].Comment)
+
+	assert.Equal(t, pub, must(c.ToAuthorizedKey(pub)))
+	assert.Equal(t, "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGtZHdsWNHqkasMVnuyUGz/DrClhCfZEWilwYtBO3PRq deploy",
+		must(c.ToAuthorizedKey("deploy", []byte(pub))))
+	_, err = c.ToAuthorizedKey()
+	assert.Error(t, err)
+}
+
+func TestOTP(t *testing.T) {
+	c := CryptoNS()
+	// base32 of "12345678901234567890", the RFC 4226 test key
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	assert.Equal(t, "755224", must(c.HOTP(0, secret)))
+	assert.Equal(t, "287082", must(c.HOTP("1", secret)))
+	assert.Equal(t, "84755224", must(c.HOTP(map[string]interface{}{"digits": 8}, 0, secret)))
+	_, err := c.HOTP(-1, secret)
+	assert.Error(t, err)
+	_, err = c.HOTP(0, "!!!")
+	assert.Error(t, err)
+	_, err = c.HOTP(secret)
+	assert.Error(t, err)
+	_, err = c.HOTP("digits", 0, secret)
+	assert.Error(t, err)
+
+	assert.Equal(t, "94287082", must(c.TOTP(map[string]interface{}{"digits": 8, "time": 59}, secret)))
+	assert.Equal(t, "07081804", must(c.TOTP(map[string]interface{}{"digits": 8, "time": time.Unix(1111111109, 0)}, secret)))
+	assert.Equal(t, "755224", must(c.TOTP(map[string]interface{}{"period": 60, "time": 59}, secret)))
+	assert.Equal(t, "755224", must(c.TOTP(map[string]interface{}{"period": "1m", "time": 59}, secret)))
+	assert.Len(t, must(c.TOTP(secret)), 6)
+
+	_, err = c.TOTP(map[string]interface{}{"bogus": 1}, secret)
+	assert.Error(t, err)
+	_, err = c.TOTP(map[string]interface{}{"period": "soon"}, secret)
+	assert.Error(t, err)
+	_, err = c.TOTP(map[string]interface{}{"algorithm": "md5"}, secret)
+	assert.Error(t, err)
+	_, err = c.TOTP()
+	assert.Error(t, err)
 }
@@ -3,6 +3,8 @@ package funcs
 import (
 	"sync"
 
+	"github.com/pkg/errors"
+
 	"github.com/hairyhenderson/gomplate/v3/conv"
 	"github.com/hairyhenderson/gomplate/v3/data"
 )
@@ -73,25 +75,76 @@ func (f *DataFuncs) TOML(in interface{}) (interface{}, error) {
 }
 
 // CSV -
-func (f *DataFuncs) CSV(args ...string) ([][]string, error) {
-	return data.CSV(args...)
+func (f *DataFuncs) CSV(args ...interface{}) ([][]string, error) {
+	opts, in, err := csvOptions(args)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		return data.CSV(conv.ToStrings(args...)...)
+	}
+	return data.CSVWithOptions(in, *opts)
 }
 
 // CSVByRow -
-func (f *DataFuncs) CSVByRow(args ...string) (rows []map[string]string, err error) {
-	return data.CSVByRow(args...)
+func (f *DataFuncs) CSVByRow(args ...interface{}) (rows []map[string]string, err error) {
+	opts, in, err := csvOptions(args)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		return data.CSVByRow(conv.ToStrings(args...)...)
+	}
+	return data.CSVByRowWithOptions(in, *opts)
 }
 
 // CSVByColumn -
-func (f *DataFuncs) CSVByColumn(args ...string) (cols map[string][]string, err error) {
-	return data.CSVByColumn(args...)
+func (f *DataFuncs) CSVByColumn(args ...interface{}) (cols map[string][]string, err error) {
+	opts, in, err := csvOptions(args)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		return data.CSVByColumn(conv.ToStrings(args...)...)
+	}
+	return data.CSVByColumnWithOptions(in, *opts)
 }
 
 // ToCSV -
 func (f *DataFuncs) ToCSV(args ...interface{}) (string, error) {
+	if len(args) == 2 {
+		if m, ok := args[0].(map[string]interface{}); ok {
+			opts, err := data.ParseCSVOptions(m)
+			if err != nil {
+				return "", err
+			}
+			return data.ToCSVWithOptions(args[1], opts)
+		}
+	}
 	return data.ToCSV(args...)
 }
 
+// csvOptions - when the first argument is a map of options, parse them and
+// return them with the input, which must be the only other argument.
+// Otherwise the options are nil, and the args are positional.
+func csvOptions(args []interface{}) (*data.CSVOptions, string, error) {
+	if len(args) == 0 {
+		return nil, "", nil
+	}
+	m, ok := args[0].(map[string]interface{})
+	if !ok {
+		return nil, "", nil
+	}
+	if len(args) != 2 {
+		return nil, "", errors.Errorf("wrong number of args: wanted 2 (options and input), got %d", len(args))
+	}
+	opts, err := data.ParseCSVOptions(m)
+	if err != nil {
+		return nil, "", err
+	}
+	return &opts, conv.ToString(args[1]), nil
+}
+
 // ToJSON -
 func (f *DataFuncs) ToJSON(in interface{}) (string, error) {
 	return data.ToJSON(in)
@@ -38,3 +38,8 @@ func (f *EnvFuncs) Getenv(key interface{}, def ...string) string {
 func (f *EnvFuncs) ExpandEnv(s interface{}) string {
 	return env.ExpandEnv(conv.ToString(s))
 }
+
+// Expand -
+func (f *EnvFuncs) Expand(s interface{}) (string, error) {
+	return env.Expand(conv.ToString(s))
+}
@@ -14,3 +14,13 @@ func TestEnvGetenv(t *testing.T) {
 
 	assert.Equal(t, "foo", ef.Getenv("bogusenvvar", "foo"))
 }
+
+func TestEnvExpand(t *testing.T) {
+	ef := &EnvFuncs{}
+	out, err := ef.Expand("${GOMPLATE_BOGUS_VAR:-fallback}")
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback", out)
+
+	_, err = ef.Expand("${GOMPLATE_BOGUS_VAR:?required}")
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package funcs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hairyhenderson/gomplate/v3/conv"
+	"github.com/hairyhenderson/gomplate/v3/data"
+	"github.com/pkg/errors"
+)
+
+// AddExecFuncs - add the exec namespace. Unless enabled, all exec functions
+// return an error, so that templates using them fail with a useful message.
+func AddExecFuncs(ctx context.Context, f map[string]interface{}, enabled bool) {
+	ns := &ExecFuncs{ctx: ctx, enabled: enabled}
+	f["exec"] = func() interface{} { return ns }
+}
+
+// ExecFuncs -
+type ExecFuncs struct {
+	ctx     context.Context
+	enabled bool
+}
+
+// environment variables passed to commands by default - enough for most
+// commands to function, without leaking secrets that may be in the environment
+var execDefaultEnv = []string{"PATH", "HOME", "TMPDIR", "TEMP", "TMP", "SYSTEMROOT"}
+
+type execOptions struct {
+	env        map[string]string
+	inheritEnv bool
+	dir        string
+	stdin      string
+	mimeType   string
+	timeout    time.Duration
+}
+
+// Run - run a command (without a shell), returning its output. An optional
+// map of options may be given as the first argument.
+func (f *ExecFuncs) Run(args ...interface{}) (interface{}, error) {
+	if !f.enabled {
+		return nil, errors.New("exec.Run is disabled - use --enable-exec (or enableExec in the config file) to enable it")
+	}
+	if len(args) == 0 {
+		return nil, errors.Errorf("wrong number of args: wanted at least 1, got %d", len(args))
+	}
+
+	opts := execOptions{}
+	if m, ok := args[0].(map[string]interface{}); ok {
+		var err error
+		opts, err = parseExecOptions(m)
+		if err != nil {
+			return nil, errors.Wrap(err, "exec.Run")
+		}
+		args = args[1:]
+		if len(args) == 0 {
+			return nil, errors.New("exec.Run: no command given")
+		}
+	}
+
+	a := conv.ToStrings(args...)
+	ctx := f.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
+	// nolint: gosec
+	c := exec.CommandContext(ctx, a[0], a[1:]...)
+	c.Dir = opts.dir
+	c.Env = opts.environ()
+	c.Stdin = strings.NewReader(opts.stdin)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	c.Stdout = stdout
+	c.Stderr = stderr
+
+	err := c.Run()
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("exec.Run: %s timed out: %w", a[0], ctx.Err())
+	}
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return nil, errors.Wrapf(err, "exec.Run: %s failed", a[0])
+		}
+		return nil, errors.Wrapf(err, "exec.Run: %s failed: %s", a[0], msg)
+	}
+
+	if opts.mimeType != "" {
+		out, err := data.ParseData(opts.mimeType, stdout.String())
+		return out, errors.Wrapf(err, "exec.Run: failed to parse output of %s", a[0])
+	}
+	return stdout.String(), nil
+}
+
+func parseExecOptions(m map[string]interface{}) (opts execOptions, err error) {
+	for k, v := range m {
+		switch k {
+		case "env":
+			env, ok := v.(map[string]interface{})
+			if !ok {
+				return opts, errors.Errorf("env must be a map, got %T", v)
+			}
+			opts.env = make(map[string]string, len(env))
+			for ek, ev := range env {
+				opts.env[ek] = conv.ToString(ev)
+			}
+		case "inheritEnv":
+			opts.inheritEnv = conv.ToBool(v)
+		case "dir":
+			opts.dir = conv.ToString(v)
+		case "stdin":
+			opts.stdin = conv.ToString(v)
+		case "type":
+			opts.mimeType = conv.ToString(v)
+		case "timeout":
+			opts.timeout, err = time.ParseDuration(conv.ToString(v))
+			if err != nil {
+				return opts, errors.Wrap(err, "invalid timeout")
+			}
+		default:
+			return opts, errors.Errorf("unknown option %q", k)
+		}
+	}
+	return opts, nil
+}
+
+// environ - the environment for the command, in os.Environ format
+func (o execOptions) environ() []string {
+	env := []string{}
+	if o.inheritEnv {
+		env = append(env, os.Environ()...)
+	} else {
+		for _, k := range execDefaultEnv {
+			if v, ok := os.LookupEnv(k); ok {
+				env = append(env, k+"="+v)
+			}
+		}
+	}
+	for k, v := range o.env {
+		env = append(env, k+"="+v)
+	}
+	return env
+}
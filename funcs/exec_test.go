@@ -0,0 +1,78 @@
+//+build !windows
+
+package funcs
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecRun(t *testing.T) {
+	f := &ExecFuncs{ctx: context.Background()}
+	_, err := f.Run("echo", "hello")
+	assert.Error(t, err)
+
+	f.enabled = true
+
+	_, err = f.Run()
+	assert.Error(t, err)
+
+	out, err := f.Run("echo", "hello", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello 42\n", out)
+
+	out, err = f.Run(map[string]interface{}{"stdin": "hi there"}, "cat")
+	assert.NoError(t, err)
+	assert.Equal(t, "hi there", out)
+
+	out, err = f.Run(map[string]interface{}{"dir": "/"}, "pwd")
+	assert.NoError(t, err)
+	assert.Equal(t, "/\n", out)
+
+	out, err = f.Run(map[string]interface{}{"type": "application/json"}, "echo", `{"foo": [1, 2]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"foo": []interface{}{1.0, 2.0}}, out)
+
+	_, err = f.Run(map[string]interface{}{"type": "application/json"}, "echo", `not json`)
+	assert.Error(t, err)
+
+	_, err = f.Run(map[string]interface{}{"dir": "/"})
+	assert.Error(t, err)
+
+	_, err = f.Run(map[string]interface{}{"bogus": "/"}, "true")
+	assert.Error(t, err)
+
+	_, err = f.Run("false")
+	assert.Error(t, err)
+
+	_, err = f.Run("sh", "-c", "echo oops >&2; exit 1")
+	assert.Contains(t, err.Error(), "oops")
+
+	_, err = f.Run(map[string]interface{}{"timeout": "10ms"}, "sleep", "1")
+	assert.Contains(t, err.Error(), "timed out")
+}
+
+func TestExecRunEnv(t *testing.T) {
+	os.Setenv("GOMPLATE_EXEC_SECRET", "shh")
+	defer os.Unsetenv("GOMPLATE_EXEC_SECRET")
+
+	f := &ExecFuncs{ctx: context.Background(), enabled: true}
+
+	out, err := f.Run("sh", "-c", "echo ${GOMPLATE_EXEC_SECRET:-unset}")
+	assert.NoError(t, err)
+	assert.Equal(t, "unset\n", out)
+
+	out, err = f.Run(map[string]interface{}{"inheritEnv": true}, "sh", "-c", "echo ${GOMPLATE_EXEC_SECRET:-unset}")
+	assert.NoError(t, err)
+	assert.Equal(t, "shh\n", out)
+
+	out, err = f.Run(map[string]interface{}{"env": map[string]interface{}{"FOO": "bar"}}, "sh", "-c", "echo $FOO")
+	assert.NoError(t, err)
+	assert.Equal(t, "bar\n", out)
+
+	_, err = f.Run(map[string]interface{}{"env": "FOO=bar"}, "true")
+	assert.Error(t, err)
+}
@@ -2,10 +2,12 @@ package funcs
 
 import (
 	"os"
+	"regexp"
 	"sync"
 
 	"github.com/hairyhenderson/gomplate/v3/conv"
 	"github.com/hairyhenderson/gomplate/v3/file"
+	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 )
 
@@ -57,17 +59,57 @@ func (f *FileFuncs) ReadDir(path interface{}) ([]string, error) {
 	return file.ReadDir(conv.ToString(path))
 }
 
-// Walk -
-func (f *FileFuncs) Walk(path interface{}) ([]string, error) {
-	files := make([]string, 0)
-	err := afero.Walk(f.fs, conv.ToString(path), func(subpath string, finfo os.FileInfo, err error) error {
+// Walk - list the files and directories under a path. When an options map is
+// given as the first argument, entries with metadata are returned instead of
+// plain paths.
+func (f *FileFuncs) Walk(args ...interface{}) (interface{}, error) {
+	switch len(args) {
+	case 1:
+		files := make([]string, 0)
+		err := afero.Walk(f.fs, conv.ToString(args[0]), func(subpath string, finfo os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			files = append(files, subpath)
+			return nil
+		})
+		return files, err
+	case 2:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("file.Walk: options must be a map, got %T", args[0])
+		}
+		opts, err := parseWalkOptions(m)
 		if err != nil {
-			return err
+			return nil, errors.Wrap(err, "file.Walk")
+		}
+		return file.Walk(f.fs, conv.ToString(args[1]), opts)
+	default:
+		return nil, errors.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+	}
+}
+
+func parseWalkOptions(m map[string]interface{}) (file.WalkOptions, error) {
+	opts := file.WalkOptions{MaxDepth: -1}
+	for k, v := range m {
+		switch k {
+		case "glob":
+			opts.Glob = conv.ToString(v)
+		case "regexp":
+			re, err := regexp.Compile(conv.ToString(v))
+			if err != nil {
+				return opts, err
+			}
+			opts.Regexp = re
+		case "maxDepth":
+			opts.MaxDepth = conv.ToInt(v)
+		case "type":
+			opts.Type = conv.ToString(v)
+		default:
+			return opts, errors.Errorf("unknown option %q", k)
 		}
-		files = append(files, subpath)
-		return nil
-	})
-	return files, err
+	}
+	return opts, nil
 }
 
 // Write -
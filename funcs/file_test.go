@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/hairyhenderson/gomplate/v3/file"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
@@ -52,4 +53,27 @@ func TestFileWalk(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedPaths, actualPaths)
+
+	out, err := ff.Walk(map[string]interface{}{"type": "file", "glob": "f*"}, "/tmp")
+	assert.NoError(t, err)
+	entries := out.([]file.Entry)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "bar/baz/foo", entries[0].RelPath)
+	assert.Equal(t, int64(3), entries[0].Size)
+
+	out, err = ff.Walk(map[string]interface{}{"maxDepth": 1, "regexp": "^b"}, "/tmp")
+	assert.NoError(t, err)
+	assert.Len(t, out, 1)
+
+	_, err = ff.Walk(map[string]interface{}{"bogus": true}, "/tmp")
+	assert.Error(t, err)
+
+	_, err = ff.Walk(map[string]interface{}{"regexp": "("}, "/tmp")
+	assert.Error(t, err)
+
+	_, err = ff.Walk("/tmp", "/tmp")
+	assert.Error(t, err)
+
+	_, err = ff.Walk()
+	assert.Error(t, err)
 }
@@ -5,6 +5,8 @@ import (
 	"sync"
 
 	"github.com/hairyhenderson/gomplate/v3/conv"
+	"github.com/hairyhenderson/gomplate/v3/internal/glob"
+	"github.com/spf13/afero"
 )
 
 var (
@@ -14,7 +16,7 @@ var (
 
 // FilePathNS - the Path namespace
 func FilePathNS() *FilePathFuncs {
-	fpfInit.Do(func() { fpf = &FilePathFuncs{} })
+	fpfInit.Do(func() { fpf = &FilePathFuncs{afero.NewOsFs()} })
 	return fpf
 }
 
@@ -25,6 +27,7 @@ func AddFilePathFuncs(f map[string]interface{}) {
 
 // FilePathFuncs -
 type FilePathFuncs struct {
+	fs afero.Fs
 }
 
 // Base -
@@ -57,6 +60,11 @@ func (f *FilePathFuncs) IsAbs(in interface{}) bool {
 	return filepath.IsAbs(conv.ToString(in))
 }
 
+// Glob -
+func (f *FilePathFuncs) Glob(pattern interface{}) ([]string, error) {
+	return glob.Glob(f.fs, conv.ToString(pattern))
+}
+
 // Join -
 func (f *FilePathFuncs) Join(elem ...interface{}) string {
 	s := conv.ToStrings(elem...)
@@ -5,6 +5,7 @@ package funcs
 import (
 	"testing"
 
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,3 +29,18 @@ func TestFilePathFuncs(t *testing.T) {
 	assert.Equal(t, []string{"/foo/bar/", "baz"}, f.Split("/foo/bar/baz"))
 	assert.Equal(t, "", f.VolumeName("/foo/bar"))
 }
+
+func TestFilePathGlob(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	f := &FilePathFuncs{fs}
+	_ = afero.WriteFile(fs, "/in/a.yaml", []byte{}, 0644)
+	_ = afero.WriteFile(fs, "/in/sub/b.yml", []byte{}, 0644)
+	_ = afero.WriteFile(fs, "/in/vendor/c.yaml", []byte{}, 0644)
+
+	out, err := f.Glob("/in/**/*.{yaml,yml}")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/in/a.yaml", "/in/sub/b.yml", "/in/vendor/c.yaml"}, out)
+
+	_, err = f.Glob("/in/[")
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package funcs
+
+import (
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v3/gcp"
+)
+
+var (
+	gcpNS     *GCPFuncs
+	gcpNSInit sync.Once
+)
+
+// GCPNS - the gcp namespace
+func GCPNS() *GCPFuncs {
+	gcpNSInit.Do(func() {
+		gcpNS = &GCPFuncs{
+			gcpopts: gcp.GetClientOptions(),
+		}
+	})
+	return gcpNS
+}
+
+// AddGCPFuncs -
+func AddGCPFuncs(f map[string]interface{}) {
+	f["gcp"] = GCPNS
+}
+
+// GCPFuncs -
+type GCPFuncs struct {
+	meta     *gcp.Meta
+	metaInit sync.Once
+	gcpopts  gcp.ClientOptions
+}
+
+// Meta -
+func (g *GCPFuncs) Meta(key string, def ...string) (string, error) {
+	g.metaInit.Do(g.initMeta)
+	return g.meta.Meta(key, def...)
+}
+
+// ProjectID -
+func (g *GCPFuncs) ProjectID(def ...string) (string, error) {
+	g.metaInit.Do(g.initMeta)
+	return g.meta.ProjectID(def...)
+}
+
+// Zone -
+func (g *GCPFuncs) Zone(def ...string) (string, error) {
+	g.metaInit.Do(g.initMeta)
+	return g.meta.Zone(def...)
+}
+
+// Region -
+func (g *GCPFuncs) Region(def ...string) (string, error) {
+	g.metaInit.Do(g.initMeta)
+	return g.meta.Region(def...)
+}
+
+// InstanceName -
+func (g *GCPFuncs) InstanceName(def ...string) (string, error) {
+	g.metaInit.Do(g.initMeta)
+	return g.meta.InstanceName(def...)
+}
+
+// InstanceID -
+func (g *GCPFuncs) InstanceID(def ...string) (string, error) {
+	g.metaInit.Do(g.initMeta)
+	return g.meta.InstanceID(def...)
+}
+
+// Attribute -
+func (g *GCPFuncs) Attribute(key string, def ...string) (string, error) {
+	g.metaInit.Do(g.initMeta)
+	return g.meta.Attribute(key, def...)
+}
+
+// ProjectAttribute -
+func (g *GCPFuncs) ProjectAttribute(key string, def ...string) (string, error) {
+	g.metaInit.Do(g.initMeta)
+	return g.meta.ProjectAttribute(key, def...)
+}
+
+// ServiceAccount -
+func (g *GCPFuncs) ServiceAccount(def ...string) (string, error) {
+	g.metaInit.Do(g.initMeta)
+	return g.meta.ServiceAccount(def...)
+}
+
+// Scopes -
+func (g *GCPFuncs) Scopes() ([]string, error) {
+	g.metaInit.Do(g.initMeta)
+	return g.meta.Scopes()
+}
+
+func (g *GCPFuncs) initMeta() {
+	if g.meta == nil {
+		g.meta = gcp.NewMeta(g.gcpopts)
+	}
+}
@@ -0,0 +1,26 @@
+package funcs
+
+import (
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v3/gcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCPNSIsIdempotent(t *testing.T) {
+	left := GCPNS()
+	right := GCPNS()
+	assert.True(t, left == right)
+}
+
+func TestGCPFuncs(t *testing.T) {
+	// nothing listens on this port, so this behaves as if not in GCP
+	m := gcp.NewMeta(gcp.ClientOptions{})
+	m.Endpoint = "http://127.0.0.1:1"
+	g := &GCPFuncs{meta: m}
+	assert.Equal(t, "default", must(g.ProjectID("default")))
+	assert.Equal(t, "", must(g.Zone()))
+	assert.Equal(t, "us-east1", must(g.Region("us-east1")))
+	assert.Equal(t, "", must(g.Attribute("foo")))
+	assert.Equal(t, []string{}, must(g.Scopes()))
+}
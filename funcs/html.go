@@ -0,0 +1,89 @@
+package funcs
+
+import (
+	"sync"
+	"text/template"
+
+	"github.com/hairyhenderson/gomplate/v3/conv"
+	"github.com/hairyhenderson/gomplate/v3/html"
+	"github.com/pkg/errors"
+)
+
+var (
+	htmlNS     *HTMLFuncs
+	htmlNSInit sync.Once
+)
+
+// HTMLNS - the html namespace
+func HTMLNS() *HTMLFuncs {
+	htmlNSInit.Do(func() { htmlNS = &HTMLFuncs{} })
+	return htmlNS
+}
+
+// AddHTMLFuncs -
+func AddHTMLFuncs(f map[string]interface{}) {
+	f["html"] = htmlFunc
+}
+
+// htmlFunc - returns the html namespace when called with no arguments, so
+// that the built-in `html` function (which escapes its arguments) keeps
+// working
+func htmlFunc(args ...interface{}) interface{} {
+	if len(args) == 0 {
+		return HTMLNS()
+	}
+	return template.HTMLEscaper(args...)
+}
+
+// HTMLFuncs -
+type HTMLFuncs struct{}
+
+var htmlPolicies = map[string]*html.Policy{
+	"strict": html.StrictPolicy,
+	"ugc":    html.UGCPolicy,
+}
+
+// Sanitize -
+func (f *HTMLFuncs) Sanitize(args ...interface{}) (string, error) {
+	policy := "ugc"
+	var in interface{}
+	switch len(args) {
+	case 1:
+		in = args[0]
+	case 2:
+		policy = conv.ToString(args[0])
+		in = args[1]
+	default:
+		return "", errors.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+	}
+	p, ok := htmlPolicies[policy]
+	if !ok {
+		return "", errors.Errorf("unknown sanitization policy %q: must be one of strict or ugc", policy)
+	}
+	return p.Sanitize(conv.ToString(in)), nil
+}
+
+// StripTags -
+func (f *HTMLFuncs) StripTags(in interface{}) string {
+	return html.StrictPolicy.Sanitize(conv.ToString(in))
+}
+
+// Escape -
+func (f *HTMLFuncs) Escape(in interface{}) string {
+	return html.Escape(conv.ToString(in))
+}
+
+// Unescape -
+func (f *HTMLFuncs) Unescape(in interface{}) string {
+	return html.Unescape(conv.ToString(in))
+}
+
+// EscapeJS -
+func (f *HTMLFuncs) EscapeJS(in interface{}) string {
+	return html.EscapeJS(conv.ToString(in))
+}
+
+// EscapeCSS -
+func (f *HTMLFuncs) EscapeCSS(in interface{}) string {
+	return html.EscapeCSS(conv.ToString(in))
+}
@@ -0,0 +1,49 @@
+package funcs
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLSanitize(t *testing.T) {
+	f := HTMLNS()
+	in := `<p onclick="x()">Hi <a href="javascript:x()">there</a><script>x()</script></p>`
+
+	out, err := f.Sanitize(in)
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>Hi <a>there</a></p>", out)
+
+	out, err = f.Sanitize("strict", in)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hi there", out)
+	assert.Equal(t, "Hi there", f.StripTags(in))
+
+	_, err = f.Sanitize("bogus", in)
+	assert.Error(t, err)
+
+	_, err = f.Sanitize()
+	assert.Error(t, err)
+}
+
+func TestHTMLEscape(t *testing.T) {
+	f := HTMLNS()
+	assert.Equal(t, "&lt;b&gt;&amp;&#39;&#34;", f.Escape(`<b>&'"`))
+	assert.Equal(t, `<b>&'"`, f.Unescape("&lt;b&gt;&amp;&#39;&#34;"))
+	assert.Equal(t, `\'\"`, f.EscapeJS(`'"`))
+	assert.Equal(t, `\27 \22 `, f.EscapeCSS(`'"`))
+	assert.Equal(t, "42", f.Escape(42))
+}
+
+func TestHTMLBuiltinCompat(t *testing.T) {
+	fm := template.FuncMap{}
+	AddHTMLFuncs(fm)
+	tmpl, err := template.New("t").Funcs(fm).Parse(`{{ html "<a>" }} {{ "<b>" | html }} {{ html.Escape "<c>" }}`)
+	assert.NoError(t, err)
+	out := &bytes.Buffer{}
+	err = tmpl.Execute(out, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "&lt;a&gt; &lt;b&gt; &lt;c&gt;", out.String())
+}
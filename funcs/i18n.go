@@ -0,0 +1,47 @@
+package funcs
+
+import (
+	"github.com/hairyhenderson/gomplate/v3/conv"
+	"github.com/hairyhenderson/gomplate/v3/data"
+	"github.com/hairyhenderson/gomplate/v3/i18n"
+	"github.com/pkg/errors"
+)
+
+// AddI18nFuncs - add the i18n namespace. Catalogs are loaded from the given
+// datasources.
+func AddI18nFuncs(f map[string]interface{}, d *data.Data) {
+	ns := &I18nFuncs{include: d.Include}
+	f["i18n"] = func() interface{} { return ns }
+}
+
+// I18nFuncs -
+type I18nFuncs struct {
+	// include - read the raw contents of a datasource
+	include func(alias string, args ...string) (string, error)
+	catalog *i18n.Catalog
+}
+
+// Load - load the message catalog from the datasource, to be used by all
+// subsequent translations
+func (f *I18nFuncs) Load(alias string, args ...string) (string, error) {
+	in, err := f.include(alias, args...)
+	if err != nil {
+		return "", err
+	}
+	c, err := i18n.Parse([]byte(in))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to load message catalog from datasource %s", alias)
+	}
+	f.catalog = c
+	return "", nil
+}
+
+// T -
+func (f *I18nFuncs) T(id interface{}, args ...interface{}) string {
+	return f.catalog.Translate(conv.ToString(id), args...)
+}
+
+// TN -
+func (f *I18nFuncs) TN(id, plural, n interface{}, args ...interface{}) string {
+	return f.catalog.TranslatePlural(conv.ToString(id), conv.ToString(plural), conv.ToInt(n), args...)
+}
@@ -0,0 +1,38 @@
+package funcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestI18n(t *testing.T) {
+	sources := map[string]string{
+		"fr":  `{"Hello, %s!": "Bonjour, %s !", "%d file": ["%d fichier", "%d fichiers"], "": "Plural-Forms: nplurals=2; plural=(n > 1);"}`,
+		"de":  "msgid \"Hello, %s!\"\nmsgstr \"Hallo, %s!\"\n",
+		"bad": "msgid",
+	}
+	f := &I18nFuncs{include: func(alias string, args ...string) (string, error) {
+		return sources[alias], nil
+	}}
+
+	// untranslated before a catalog is loaded
+	assert.Equal(t, "Hello, Jane!", f.T("Hello, %s!", "Jane"))
+	assert.Equal(t, "0 files", f.TN("%d file", "%d files", 0, 0))
+
+	out, err := f.Load("fr")
+	assert.NoError(t, err)
+	assert.Equal(t, "", out)
+	assert.Equal(t, "Bonjour, Jane !", f.T("Hello, %s!", "Jane"))
+	assert.Equal(t, "0 fichier", f.TN("%d file", "%d files", "0", 0))
+	assert.Equal(t, "3 fichiers", f.TN("%d file", "%d files", 3, 3))
+	assert.Equal(t, "missing", f.T("missing"))
+
+	_, err = f.Load("de")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hallo, Jane!", f.T("Hello, %s!", "Jane"))
+	assert.Equal(t, "0 files", f.TN("%d file", "%d files", 0, 0))
+
+	_, err = f.Load("bad")
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package funcs
+
+import (
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v3/conv"
+	"github.com/hairyhenderson/gomplate/v3/markdown"
+	"github.com/pkg/errors"
+)
+
+var (
+	mdNS     *MarkdownFuncs
+	mdNSInit sync.Once
+)
+
+// MarkdownNS - the md namespace
+func MarkdownNS() *MarkdownFuncs {
+	mdNSInit.Do(func() { mdNS = &MarkdownFuncs{} })
+	return mdNS
+}
+
+// AddMarkdownFuncs -
+func AddMarkdownFuncs(f map[string]interface{}) {
+	f["md"] = MarkdownNS
+}
+
+// MarkdownFuncs -
+type MarkdownFuncs struct{}
+
+// ToHTML -
+func (f *MarkdownFuncs) ToHTML(args ...interface{}) (string, error) {
+	opts := markdown.Options{}
+	var in interface{}
+	switch len(args) {
+	case 1:
+		in = args[0]
+	case 2:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			return "", errors.Errorf("expected an options map as the first argument, got %T", args[0])
+		}
+		var err error
+		opts, err = parseMarkdownOptions(m)
+		if err != nil {
+			return "", err
+		}
+		in = args[1]
+	default:
+		return "", errors.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+	}
+	out, err := markdown.ToHTML(toBytes(in), opts)
+	return string(out), err
+}
+
+func parseMarkdownOptions(m map[string]interface{}) (markdown.Options, error) {
+	opts := markdown.Options{}
+	for k, v := range m {
+		switch k {
+		case "gfm":
+			opts.GFM = conv.ToBool(v)
+		case "unsafe":
+			opts.Unsafe = conv.ToBool(v)
+		case "hardWraps":
+			opts.HardWraps = conv.ToBool(v)
+		default:
+			return opts, errors.Errorf("unknown option %q", k)
+		}
+	}
+	return opts, nil
+}
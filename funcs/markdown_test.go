@@ -0,0 +1,32 @@
+package funcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	f := MarkdownNS()
+
+	out, err := f.ToHTML("# Hi\n\n~~old~~ <b>new</b>")
+	assert.NoError(t, err)
+	assert.Equal(t, "<h1>Hi</h1>\n<p>~~old~~ <!-- raw HTML omitted -->new<!-- raw HTML omitted --></p>\n", out)
+
+	out, err = f.ToHTML(map[string]interface{}{"gfm": true, "unsafe": "true"}, []byte("~~old~~ <b>new</b>"))
+	assert.NoError(t, err)
+	assert.Equal(t, "<p><del>old</del> <b>new</b></p>\n", out)
+
+	out, err = f.ToHTML(map[string]interface{}{"hardWraps": true}, "a\nb")
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>a<br>\nb</p>\n", out)
+
+	_, err = f.ToHTML(map[string]interface{}{"bogus": true}, "a")
+	assert.Error(t, err)
+
+	_, err = f.ToHTML("gfm", "a")
+	assert.Error(t, err)
+
+	_, err = f.ToHTML()
+	assert.Error(t, err)
+}
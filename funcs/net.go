@@ -1,10 +1,12 @@
 package funcs
 
 import (
+	"math/big"
 	stdnet "net"
 	"sync"
 
 	"github.com/hairyhenderson/gomplate/v3/conv"
+	"github.com/pkg/errors"
 
 	"github.com/hairyhenderson/gomplate/v3/net"
 )
@@ -57,3 +59,105 @@ func (f *NetFuncs) LookupSRVs(name interface{}) ([]*stdnet.SRV, error) {
 func (f *NetFuncs) LookupTXT(name interface{}) ([]string, error) {
 	return net.LookupTXT(conv.ToString(name))
 }
+
+// ToASCII -
+func (f *NetFuncs) ToASCII(name interface{}) (string, error) {
+	return net.ToASCII(conv.ToString(name))
+}
+
+// ToUnicode -
+func (f *NetFuncs) ToUnicode(name interface{}) (string, error) {
+	return net.ToUnicode(conv.ToString(name))
+}
+
+// NextIP -
+func (f *NetFuncs) NextIP(args ...interface{}) (string, error) {
+	return addIP(1, args)
+}
+
+// PrevIP -
+func (f *NetFuncs) PrevIP(args ...interface{}) (string, error) {
+	return addIP(-1, args)
+}
+
+// addIP - add the optional count (default 1) to the IP address, in the given
+// direction
+func addIP(sign int64, args []interface{}) (string, error) {
+	n := int64(1)
+	var in interface{}
+	switch len(args) {
+	case 1:
+		in = args[0]
+	case 2:
+		n = conv.ToInt64(args[0])
+		in = args[1]
+	default:
+		return "", errors.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+	}
+	ip, err := parseIP(in)
+	if err != nil {
+		return "", err
+	}
+	out, err := net.AddIP(ip, sign*n)
+	if err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// IPRange -
+func (f *NetFuncs) IPRange(start, end interface{}) ([]string, error) {
+	s, err := parseIP(start)
+	if err != nil {
+		return nil, err
+	}
+	e, err := parseIP(end)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := net.IPRange(s, e)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, len(ips))
+	for i, ip := range ips {
+		out[i] = ip.String()
+	}
+	return out, nil
+}
+
+// IPToInt - IPv4 addresses are converted to int64s, but IPv6 addresses are
+// usually too large, so they're converted to strings of decimal digits
+func (f *NetFuncs) IPToInt(in interface{}) (interface{}, error) {
+	ip, err := parseIP(in)
+	if err != nil {
+		return nil, err
+	}
+	i := net.IPToInt(ip)
+	if ip.To4() != nil {
+		return i.Int64(), nil
+	}
+	return i.String(), nil
+}
+
+// IntToIP -
+func (f *NetFuncs) IntToIP(in interface{}) (string, error) {
+	i, ok := new(big.Int).SetString(conv.ToString(in), 10)
+	if !ok {
+		return "", errors.Errorf("invalid integer %q", conv.ToString(in))
+	}
+	ip, err := net.IntToIP(i)
+	if err != nil {
+		return "", err
+	}
+	return ip.String(), nil
+}
+
+func parseIP(in interface{}) (stdnet.IP, error) {
+	s := conv.ToString(in)
+	ip := stdnet.ParseIP(s)
+	if ip == nil {
+		return nil, errors.Errorf("invalid IP address %q", s)
+	}
+	return ip, nil
+}
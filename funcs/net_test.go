@@ -10,3 +10,51 @@ func TestNetLookupIP(t *testing.T) {
 	n := &NetFuncs{}
 	assert.Equal(t, "127.0.0.1", must(n.LookupIP("localhost")))
 }
+
+func TestNetNextPrevIP(t *testing.T) {
+	n := &NetFuncs{}
+	assert.Equal(t, "10.0.1.0", must(n.NextIP("10.0.0.255")))
+	assert.Equal(t, "10.0.0.11", must(n.NextIP(10, "10.0.0.1")))
+	assert.Equal(t, "10.0.0.255", must(n.PrevIP("10.0.1.0")))
+	assert.Equal(t, "2001:db8::fffe", must(n.PrevIP("2", "2001:db8::1:0")))
+
+	_, err := n.NextIP("255.255.255.255")
+	assert.Error(t, err)
+	_, err = n.NextIP("bogus")
+	assert.Error(t, err)
+	_, err = n.NextIP()
+	assert.Error(t, err)
+}
+
+func TestNetIPRange(t *testing.T) {
+	n := &NetFuncs{}
+	assert.Equal(t, []string{"192.168.0.254", "192.168.0.255", "192.168.1.0"},
+		must(n.IPRange("192.168.0.254", "192.168.1.0")))
+
+	_, err := n.IPRange("192.168.0.1", "bogus")
+	assert.Error(t, err)
+	_, err = n.IPRange("192.168.0.2", "192.168.0.1")
+	assert.Error(t, err)
+}
+
+func TestNetIPToInt(t *testing.T) {
+	n := &NetFuncs{}
+	assert.Equal(t, int64(3232235777), must(n.IPToInt("192.168.1.1")))
+	assert.Equal(t, "42540766411282592856903984951653826561", must(n.IPToInt("2001:db8::1")))
+	_, err := n.IPToInt("bogus")
+	assert.Error(t, err)
+
+	assert.Equal(t, "192.168.1.1", must(n.IntToIP(3232235777)))
+	assert.Equal(t, "192.168.1.1", must(n.IntToIP("3232235777")))
+	assert.Equal(t, "2001:db8::1", must(n.IntToIP("42540766411282592856903984951653826561")))
+	_, err = n.IntToIP("bogus")
+	assert.Error(t, err)
+	_, err = n.IntToIP(-1)
+	assert.Error(t, err)
+}
+
+func TestNetIDN(t *testing.T) {
+	n := &NetFuncs{}
+	assert.Equal(t, "*.xn--bcher-kva.example", must(n.ToASCII("*.Bücher.example")))
+	assert.Equal(t, "*.bücher.example", must(n.ToUnicode("*.xn--bcher-kva.example")))
+}
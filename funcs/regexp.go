@@ -1,12 +1,14 @@
 package funcs
 
 import (
+	"bytes"
 	"sync"
 
 	"github.com/pkg/errors"
 
 	"github.com/hairyhenderson/gomplate/v3/conv"
 	"github.com/hairyhenderson/gomplate/v3/regexp"
+	"github.com/hairyhenderson/gomplate/v3/tmpl"
 )
 
 var (
@@ -25,8 +27,17 @@ func AddReFuncs(f map[string]interface{}) {
 	f["regexp"] = ReNS
 }
 
+// ReNSWithTemplate - a regexp namespace that can render callback templates
+// (for ReplaceFunc) with the given template namespace
+func ReNSWithTemplate(t *tmpl.Template) func() *ReFuncs {
+	ns := &ReFuncs{tmpl: t}
+	return func() *ReFuncs { return ns }
+}
+
 // ReFuncs -
-type ReFuncs struct{}
+type ReFuncs struct {
+	tmpl *tmpl.Template
+}
 
 // Find -
 func (f *ReFuncs) Find(re, input interface{}) (string, error) {
@@ -53,6 +64,31 @@ func (f *ReFuncs) FindAll(args ...interface{}) ([]string, error) {
 	return regexp.FindAll(re, n, input)
 }
 
+// FindNamed -
+func (f *ReFuncs) FindNamed(re, input interface{}) (map[string]string, error) {
+	return regexp.FindNamed(conv.ToString(re), conv.ToString(input))
+}
+
+// FindAllSubmatch -
+func (f *ReFuncs) FindAllSubmatch(args ...interface{}) ([][]string, error) {
+	re := ""
+	n := 0
+	input := ""
+	switch len(args) {
+	case 2:
+		n = -1
+		re = conv.ToString(args[0])
+		input = conv.ToString(args[1])
+	case 3:
+		re = conv.ToString(args[0])
+		n = conv.ToInt(args[1])
+		input = conv.ToString(args[2])
+	default:
+		return nil, errors.Errorf("wrong number of args: want 2 or 3, got %d", len(args))
+	}
+	return regexp.FindAllSubmatch(re, n, input)
+}
+
 // Match -
 func (f *ReFuncs) Match(re, input interface{}) bool {
 	return regexp.Match(conv.ToString(re), conv.ToString(input))
@@ -72,6 +108,28 @@ func (f *ReFuncs) ReplaceLiteral(re, replacement, input interface{}) (string, er
 		conv.ToString(input))
 }
 
+// ReplaceFunc - replace each match with the output of the named template (or
+// an inline template), rendered with the match as its context
+func (f *ReFuncs) ReplaceFunc(re, tmplName, input interface{}) (string, error) {
+	if f.tmpl == nil {
+		return "", errors.New("regexp.ReplaceFunc is not available without a template")
+	}
+	t, err := tmpl.Resolve(f.tmpl, conv.ToString(tmplName))
+	if err != nil {
+		return "", err
+	}
+	return regexp.ReplaceFunc(conv.ToString(re), conv.ToString(input),
+		func(groups []string, named map[string]string) (string, error) {
+			out := &bytes.Buffer{}
+			err := t.Execute(out, map[string]interface{}{
+				"Match":  groups[0],
+				"Groups": groups,
+				"Named":  named,
+			})
+			return out.String(), err
+		})
+}
+
 // Split -
 func (f *ReFuncs) Split(args ...interface{}) ([]string, error) {
 	re := ""
@@ -1,7 +1,11 @@
 package funcs
 
 import (
+	"strconv"
 	"testing"
+	"text/template"
+
+	"github.com/hairyhenderson/gomplate/v3/tmpl"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -70,6 +74,63 @@ func TestFindAll(t *testing.T) {
 	assert.Nil(t, f)
 }
 
+func TestFindNamed(t *testing.T) {
+	re := &ReFuncs{}
+	m, err := re.FindNamed(`(?P<host>[^:]+):(?P<port>\d+)`, "example.com:8080")
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]string{"host": "example.com", "port": "8080"}, m)
+
+	_, err = re.FindNamed(`[a-`, "")
+	assert.Error(t, err)
+}
+
+func TestFindAllSubmatch(t *testing.T) {
+	re := &ReFuncs{}
+	f, err := re.FindAllSubmatch(`(\w+)=(\w+)`, `a=1 b=2`)
+	assert.NoError(t, err)
+	assert.EqualValues(t, [][]string{{"a=1", "a", "1"}, {"b=2", "b", "2"}}, f)
+
+	f, err = re.FindAllSubmatch(`(\w+)=(\w+)`, 1, `a=1 b=2`)
+	assert.NoError(t, err)
+	assert.EqualValues(t, [][]string{{"a=1", "a", "1"}}, f)
+
+	_, err = re.FindAllSubmatch(`[a-`, "")
+	assert.Error(t, err)
+
+	_, err = re.FindAllSubmatch("")
+	assert.Error(t, err)
+}
+
+func TestReplaceFunc(t *testing.T) {
+	root := template.New("root")
+	root.Funcs(template.FuncMap{
+		"add":  func(a, b int) int { return a + b },
+		"atoi": strconv.Atoi,
+	})
+	template.Must(root.New("bump").Parse(`v{{ .Named.major }}.{{ .Named.minor | atoi | add 1 }}`))
+	re := ReNSWithTemplate(tmpl.New(root, nil))()
+
+	out, err := re.ReplaceFunc(`v(?P<major>\d+)\.(?P<minor>\d+)`, "bump", "from v1.2 to v1.9")
+	assert.NoError(t, err)
+	assert.Equal(t, "from v1.3 to v1.10", out)
+
+	out, err = re.ReplaceFunc(`https?://([^/ ]+)`, `<{{ .Match }}> ({{ index .Groups 1 }})`, "see http://example.com/foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "see <http://example.com> (example.com)/foo", out)
+
+	_, err = re.ReplaceFunc(`[a-`, "bump", "")
+	assert.Error(t, err)
+
+	_, err = re.ReplaceFunc(`a`, "{{ bogus", "a")
+	assert.Error(t, err)
+
+	_, err = re.ReplaceFunc(`a`, "{{ index .Groups 5 }}", "a")
+	assert.Error(t, err)
+
+	_, err = (&ReFuncs{}).ReplaceFunc(`a`, "bump", "a")
+	assert.Error(t, err)
+}
+
 func TestSplit(t *testing.T) {
 	re := &ReFuncs{}
 	f, err := re.Split(` `, `foo bar baz`)
@@ -0,0 +1,46 @@
+package funcs
+
+import (
+	"strings"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/pkg/errors"
+)
+
+// AddSprigFuncs - add the Sprig (https://masterminds.github.io/sprig/)
+// functions, along with a few commonly-used functions that Helm adds to Sprig.
+// Functions whose names are already in use are skipped - gomplate's own
+// functions always take precedence.
+func AddSprigFuncs(f map[string]interface{}) {
+	add := func(k string, v interface{}) {
+		if _, ok := f[k]; !ok {
+			f[k] = v
+		}
+	}
+	for k, v := range sprig.TxtFuncMap() {
+		add(k, v)
+	}
+	add("toYaml", helmToYaml)
+	add("fromYaml", DataNS().YAML)
+	add("fromYamlArray", DataNS().YAMLArray)
+	add("toToml", DataNS().ToTOML)
+	add("required", helmRequired)
+}
+
+// helmToYaml - like toYAML, but without the trailing newline, as in Helm
+func helmToYaml(in interface{}) (string, error) {
+	out, err := DataNS().ToYAML(in)
+	return strings.TrimSuffix(out, "\n"), err
+}
+
+// helmRequired - fail with the given message when the value is nil or an
+// empty string, as in Helm
+func helmRequired(msg string, in interface{}) (interface{}, error) {
+	if in == nil {
+		return nil, errors.New(msg)
+	}
+	if s, ok := in.(string); ok && s == "" {
+		return nil, errors.New(msg)
+	}
+	return in, nil
+}
@@ -0,0 +1,42 @@
+package funcs
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSprigFuncs(t *testing.T) {
+	own := func() string { return "gomplate" }
+	f := map[string]interface{}{"default": own}
+	AddSprigFuncs(f)
+
+	// existing functions aren't overridden
+	assert.Equal(t, "gomplate", f["default"].(func() string)())
+	assert.Contains(t, f, "toYaml")
+	assert.Contains(t, f, "camelcase")
+
+	tmpl, err := template.New("t").Funcs(f).Parse(`{{ "hello_world" | camelcase }} {{ list 1 2 | toJson }} {{ dict "a" 1 | toYaml }}|`)
+	assert.NoError(t, err)
+	out := &bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(out, nil))
+	assert.Equal(t, "HelloWorld [1,2] a: 1|", out.String())
+}
+
+func TestHelmRequired(t *testing.T) {
+	out, err := helmRequired("foo is required", "bar")
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", out)
+
+	out, err = helmRequired("foo is required", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, out)
+
+	_, err = helmRequired("foo is required", "")
+	assert.EqualError(t, err, "foo is required")
+
+	_, err = helmRequired("foo is required", nil)
+	assert.EqualError(t, err, "foo is required")
+}
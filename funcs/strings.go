@@ -212,8 +212,46 @@ func (f *StringFuncs) Indent(args ...interface{}) (string, error) {
 }
 
 // Slug -
-func (f *StringFuncs) Slug(in interface{}) string {
-	return slug.Make(conv.ToString(in))
+func (f *StringFuncs) Slug(args ...interface{}) (string, error) {
+	switch len(args) {
+	case 1:
+		return slug.Make(conv.ToString(args[0])), nil
+	case 2:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			return "", errors.Errorf("expected an options map as the first argument, got %T", args[0])
+		}
+		opts := gompstrings.SlugOpts{}
+		for k, v := range m {
+			switch k {
+			case "lang":
+				opts.Lang = conv.ToString(v)
+			case "separator":
+				opts.Separator = conv.ToString(v)
+			case "maxLength":
+				opts.MaxLength = conv.ToInt(v)
+			case "dns":
+				opts.DNS = conv.ToBool(v)
+			default:
+				return "", errors.Errorf("unknown option %q", k)
+			}
+		}
+		return gompstrings.Slug(conv.ToString(args[1]), opts), nil
+	default:
+		return "", errors.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+	}
+}
+
+// Transliterate -
+func (f *StringFuncs) Transliterate(args ...interface{}) (string, error) {
+	switch len(args) {
+	case 1:
+		return gompstrings.Transliterate(conv.ToString(args[0]), ""), nil
+	case 2:
+		return gompstrings.Transliterate(conv.ToString(args[1]), conv.ToString(args[0])), nil
+	default:
+		return "", errors.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+	}
 }
 
 // Quote -
@@ -73,29 +73,53 @@ func TestAbbrev(t *testing.T) {
 
 func TestSlug(t *testing.T) {
 	sf := &StringFuncs{}
-	s := sf.Slug(nil)
+	s := must(sf.Slug(nil))
 	assert.Equal(t, "nil", s)
 
-	s = sf.Slug(0)
+	s = must(sf.Slug(0))
 	assert.Equal(t, "0", s)
 
-	s = sf.Slug(1.85e-5)
+	s = must(sf.Slug(1.85e-5))
 	assert.Equal(t, "1-85e-05", s)
 
-	s = sf.Slug("Hello, World!")
+	s = must(sf.Slug("Hello, World!"))
 	assert.Equal(t, "hello-world", s)
 
-	s = sf.Slug("foo@example.com")
+	s = must(sf.Slug("foo@example.com"))
 	assert.Equal(t, "fooatexample-com", s)
 
-	s = sf.Slug("rock & roll!")
+	s = must(sf.Slug("rock & roll!"))
 	assert.Equal(t, "rock-and-roll", s)
 
-	s = sf.Slug("foo@example.com")
+	s = must(sf.Slug("foo@example.com"))
 	assert.Equal(t, "fooatexample-com", s)
 
-	s = sf.Slug(`100%`)
+	s = must(sf.Slug(`100%`))
 	assert.Equal(t, "100", s)
+
+	s = must(sf.Slug(map[string]interface{}{"lang": "de", "dns": true}, "Über_uns & Kontakt"))
+	assert.Equal(t, "ueber-uns-und-kontakt", s)
+
+	s = must(sf.Slug(map[string]interface{}{"separator": "_", "maxLength": 10}, "one two three"))
+	assert.Equal(t, "one_two", s)
+
+	_, err := sf.Slug(map[string]interface{}{"bogus": true}, "foo")
+	assert.Error(t, err)
+
+	_, err = sf.Slug("foo", "bar")
+	assert.Error(t, err)
+
+	_, err = sf.Slug()
+	assert.Error(t, err)
+}
+
+func TestTransliterate(t *testing.T) {
+	sf := &StringFuncs{}
+	assert.Equal(t, "Grosse Strasse", must(sf.Transliterate("Große Straße")))
+	assert.Equal(t, "Ueber", must(sf.Transliterate("de", "Über")))
+
+	_, err := sf.Transliterate()
+	assert.Error(t, err)
 }
 
 func TestSort(t *testing.T) {
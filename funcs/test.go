@@ -24,7 +24,7 @@ func TestNS() *TestFuncs {
 func AddTestFuncs(f map[string]interface{}) {
 	f["test"] = TestNS
 
-	f["assert"] = TestNS().Assert
+	f["assert"] = assertFunc
 	f["fail"] = TestNS().Fail
 	f["required"] = TestNS().Required
 	f["ternary"] = TestNS().Ternary
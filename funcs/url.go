@@ -0,0 +1,207 @@
+package funcs
+
+import (
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v3/conv"
+	iconv "github.com/hairyhenderson/gomplate/v3/internal/conv"
+	"github.com/pkg/errors"
+)
+
+var (
+	urlNS     *URLFuncs
+	urlNSInit sync.Once
+)
+
+// URLNS - the url namespace
+func URLNS() *URLFuncs {
+	urlNSInit.Do(func() { urlNS = &URLFuncs{} })
+	return urlNS
+}
+
+// AddURLFuncs -
+func AddURLFuncs(f map[string]interface{}) {
+	f["url"] = URLNS
+}
+
+// URLFuncs -
+type URLFuncs struct{}
+
+// Parse -
+func (f *URLFuncs) Parse(in interface{}) (*url.URL, error) {
+	return url.Parse(conv.ToString(in))
+}
+
+// Build - assemble a URL from a map of components
+func (f *URLFuncs) Build(in map[string]interface{}) (string, error) {
+	u := &url.URL{}
+	for k, v := range in {
+		switch k {
+		case "scheme":
+			u.Scheme = conv.ToString(v)
+		case "user":
+			u.User = url.User(conv.ToString(v))
+		case "password":
+			// handled with user
+		case "host":
+			u.Host = conv.ToString(v)
+		case "path":
+			u.Path = conv.ToString(v)
+		case "query":
+			q, err := toValues(v)
+			if err != nil {
+				return "", err
+			}
+			u.RawQuery = q.Encode()
+		case "fragment":
+			u.Fragment = conv.ToString(v)
+		default:
+			return "", errors.Errorf("unknown URL component %q", k)
+		}
+	}
+	if p, ok := in["password"]; ok {
+		if u.User == nil {
+			return "", errors.New("a password can only be given with a user")
+		}
+		u.User = url.UserPassword(u.User.Username(), conv.ToString(p))
+	}
+	return u.String(), nil
+}
+
+// JoinPath - join the path elements to the URL's path
+func (f *URLFuncs) JoinPath(base interface{}, elem ...interface{}) (string, error) {
+	u, err := url.Parse(conv.ToString(base))
+	if err != nil {
+		return "", err
+	}
+	if len(elem) == 0 {
+		return u.String(), nil
+	}
+	elems := conv.ToStrings(elem...)
+	p := path.Join(append([]string{"/" + u.Path}, elems...)...)
+	if !strings.HasPrefix(u.Path, "/") && u.Host == "" {
+		p = strings.TrimPrefix(p, "/")
+	}
+	if strings.HasSuffix(elems[len(elems)-1], "/") && !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	u.Path = p
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// Query - the URL's query parameters
+func (f *URLFuncs) Query(in interface{}) (url.Values, error) {
+	u, err := url.Parse(conv.ToString(in))
+	if err != nil {
+		return nil, err
+	}
+	return u.Query(), nil
+}
+
+// SetQuery - set the query parameter, replacing any existing values
+func (f *URLFuncs) SetQuery(key, value, in interface{}) (string, error) {
+	return modifyQuery(in, func(q url.Values) {
+		q[conv.ToString(key)] = toStringList(value)
+	})
+}
+
+// AddQuery - add a value to the query parameter, keeping any existing values
+func (f *URLFuncs) AddQuery(key, value, in interface{}) (string, error) {
+	return modifyQuery(in, func(q url.Values) {
+		k := conv.ToString(key)
+		q[k] = append(q[k], toStringList(value)...)
+	})
+}
+
+// DelQuery - remove the query parameters
+func (f *URLFuncs) DelQuery(args ...interface{}) (string, error) {
+	if len(args) < 2 {
+		return "", errors.Errorf("wrong number of args: wanted at least 2, got %d", len(args))
+	}
+	keys := conv.ToStrings(args[:len(args)-1]...)
+	return modifyQuery(args[len(args)-1], func(q url.Values) {
+		for _, k := range keys {
+			q.Del(k)
+		}
+	})
+}
+
+// EncodeQuery - encode a map as a query string, sorted by key
+func (f *URLFuncs) EncodeQuery(in interface{}) (string, error) {
+	q, err := toValues(in)
+	if err != nil {
+		return "", err
+	}
+	return q.Encode(), nil
+}
+
+// DecodeQuery - decode a query string
+func (f *URLFuncs) DecodeQuery(in interface{}) (url.Values, error) {
+	return url.ParseQuery(strings.TrimPrefix(conv.ToString(in), "?"))
+}
+
+// QueryEscape -
+func (f *URLFuncs) QueryEscape(in interface{}) string {
+	return url.QueryEscape(conv.ToString(in))
+}
+
+// QueryUnescape -
+func (f *URLFuncs) QueryUnescape(in interface{}) (string, error) {
+	return url.QueryUnescape(conv.ToString(in))
+}
+
+// PathEscape -
+func (f *URLFuncs) PathEscape(in interface{}) string {
+	return url.PathEscape(conv.ToString(in))
+}
+
+// PathUnescape -
+func (f *URLFuncs) PathUnescape(in interface{}) (string, error) {
+	return url.PathUnescape(conv.ToString(in))
+}
+
+func modifyQuery(in interface{}, fn func(url.Values)) (string, error) {
+	u, err := url.Parse(conv.ToString(in))
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	fn(q)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// toValues - convert a map to url.Values - values can be single values or lists
+func toValues(in interface{}) (url.Values, error) {
+	switch m := in.(type) {
+	case url.Values:
+		return m, nil
+	case map[string][]string:
+		return url.Values(m), nil
+	case map[string]interface{}:
+		q := url.Values{}
+		for k, v := range m {
+			q[k] = toStringList(v)
+		}
+		return q, nil
+	default:
+		return nil, errors.Errorf("expected a map, got %T", in)
+	}
+}
+
+// toStringList - convert a single value or a list to a list of strings
+func toStringList(in interface{}) []string {
+	if s, ok := in.([]string); ok {
+		return s
+	}
+	if _, ok := in.(string); !ok {
+		if l, err := iconv.InterfaceSlice(in); err == nil {
+			return conv.ToStrings(l...)
+		}
+	}
+	return []string{conv.ToString(in)}
+}
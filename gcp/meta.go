@@ -0,0 +1,195 @@
+// Package gcp contains functions for reading Google Compute Engine metadata
+package gcp
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hairyhenderson/gomplate/v3/env"
+)
+
+// DefaultEndpoint -
+var DefaultEndpoint = "http://metadata.google.internal"
+
+var (
+	co     ClientOptions
+	coInit sync.Once
+)
+
+// ClientOptions -
+type ClientOptions struct {
+	Timeout time.Duration
+}
+
+// GetClientOptions - read the timeout from GCP_TIMEOUT, in milliseconds
+func GetClientOptions() ClientOptions {
+	coInit.Do(func() {
+		timeout := os.Getenv("GCP_TIMEOUT")
+		if timeout == "" {
+			timeout = "500"
+		}
+
+		t, err := strconv.Atoi(timeout)
+		if err != nil {
+			panic(errors.Wrapf(err, "Invalid GCP_TIMEOUT value '%s' - must be an integer\n", timeout))
+		}
+
+		co.Timeout = time.Duration(t) * time.Millisecond
+	})
+	return co
+}
+
+// Meta - reads from the GCE metadata server
+type Meta struct {
+	Endpoint string
+	Client   *http.Client
+	nonGCP   bool
+	cache    map[string]string
+	options  ClientOptions
+}
+
+// NewMeta -
+func NewMeta(options ClientOptions) *Meta {
+	if endpoint := env.Getenv("GCP_META_ENDPOINT"); endpoint != "" {
+		DefaultEndpoint = endpoint
+	}
+
+	return &Meta{cache: make(map[string]string), options: options}
+}
+
+func returnDefault(def []string) string {
+	if len(def) > 0 {
+		return def[0]
+	}
+	return ""
+}
+
+// retrieve the metadata, defaulting if we're not in GCP or if there's a
+// non-OK response
+func (m *Meta) retrieveMetadata(key string, def ...string) (string, error) {
+	if m.Endpoint == "" {
+		m.Endpoint = DefaultEndpoint
+	}
+	url := m.Endpoint + "/computeMetadata/v1/" + key
+
+	if value, ok := m.cache[url]; ok {
+		return value, nil
+	}
+
+	if m.nonGCP {
+		return returnDefault(def), nil
+	}
+
+	if m.Client == nil {
+		timeout := m.options.Timeout
+		if timeout == 0 {
+			timeout = 500 * time.Millisecond
+		}
+		m.Client = &http.Client{Timeout: timeout}
+	}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		// the metadata server can't be reached, so we're probably not in GCP
+		m.nonGCP = true
+		return returnDefault(def), nil
+	}
+
+	// nolint: errcheck
+	defer resp.Body.Close()
+	if resp.StatusCode > 399 {
+		return returnDefault(def), nil
+	}
+	if resp.Header.Get("Metadata-Flavor") != "Google" {
+		// something other than the metadata server answered
+		m.nonGCP = true
+		return returnDefault(def), nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to read response body from %s", url)
+	}
+	value := strings.TrimSpace(string(body))
+	m.cache[url] = value
+
+	return value, nil
+}
+
+// Meta - read the given metadata key, e.g. "instance/hostname"
+func (m *Meta) Meta(key string, def ...string) (string, error) {
+	return m.retrieveMetadata(strings.TrimPrefix(key, "/"), def...)
+}
+
+// ProjectID -
+func (m *Meta) ProjectID(def ...string) (string, error) {
+	return m.retrieveMetadata("project/project-id", def...)
+}
+
+// Zone - the instance's zone, e.g. "us-central1-a"
+func (m *Meta) Zone(def ...string) (string, error) {
+	// the metadata server returns "projects/<num>/zones/<zone>"
+	zone, err := m.retrieveMetadata("instance/zone", def...)
+	if err != nil {
+		return "", err
+	}
+	return zone[strings.LastIndex(zone, "/")+1:], nil
+}
+
+// Region - the instance's region, e.g. "us-central1", derived from the zone
+func (m *Meta) Region(def ...string) (string, error) {
+	zone, err := m.Zone()
+	if err != nil {
+		return "", err
+	}
+	i := strings.LastIndex(zone, "-")
+	if i < 0 {
+		return returnDefault(def), nil
+	}
+	return zone[:i], nil
+}
+
+// InstanceName -
+func (m *Meta) InstanceName(def ...string) (string, error) {
+	return m.retrieveMetadata("instance/name", def...)
+}
+
+// InstanceID -
+func (m *Meta) InstanceID(def ...string) (string, error) {
+	return m.retrieveMetadata("instance/id", def...)
+}
+
+// Attribute - the value of the custom instance metadata attribute
+func (m *Meta) Attribute(key string, def ...string) (string, error) {
+	return m.retrieveMetadata("instance/attributes/"+key, def...)
+}
+
+// ProjectAttribute - the value of the custom project metadata attribute
+func (m *Meta) ProjectAttribute(key string, def ...string) (string, error) {
+	return m.retrieveMetadata("project/attributes/"+key, def...)
+}
+
+// ServiceAccount - the email address of the instance's default service account
+func (m *Meta) ServiceAccount(def ...string) (string, error) {
+	return m.retrieveMetadata("instance/service-accounts/default/email", def...)
+}
+
+// Scopes - the OAuth scopes granted to the instance's default service account
+func (m *Meta) Scopes() ([]string, error) {
+	s, err := m.retrieveMetadata("instance/service-accounts/default/scopes")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(s), nil
+}
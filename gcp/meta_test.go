@@ -0,0 +1,98 @@
+package gcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func must(r interface{}, err error) interface{} {
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func mockServer(values map[string]string) (*httptest.Server, *Meta) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Metadata-Flavor", "Google")
+		v, ok := values[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// nolint: errcheck
+		w.Write([]byte(v))
+	}))
+	return server, &Meta{Endpoint: server.URL, cache: make(map[string]string)}
+}
+
+func TestMeta(t *testing.T) {
+	server, m := mockServer(map[string]string{
+		"/computeMetadata/v1/project/project-id":                       "my-project",
+		"/computeMetadata/v1/project/attributes/env":                   "prod",
+		"/computeMetadata/v1/instance/zone":                            "projects/1234/zones/us-central1-a",
+		"/computeMetadata/v1/instance/name":                            "web-1",
+		"/computeMetadata/v1/instance/id":                              "5678",
+		"/computeMetadata/v1/instance/hostname":                        "web-1.c.my-project.internal\n",
+		"/computeMetadata/v1/instance/attributes/role":                 "web",
+		"/computeMetadata/v1/instance/service-accounts/default/email":  "sa@my-project.iam.gserviceaccount.com",
+		"/computeMetadata/v1/instance/service-accounts/default/scopes": "https://www.googleapis.com/auth/devstorage.read_only\nhttps://www.googleapis.com/auth/logging.write\n",
+	})
+	defer server.Close()
+
+	assert.Equal(t, "my-project", must(m.ProjectID()))
+	assert.Equal(t, "prod", must(m.ProjectAttribute("env")))
+	assert.Equal(t, "us-central1-a", must(m.Zone()))
+	assert.Equal(t, "us-central1", must(m.Region()))
+	assert.Equal(t, "web-1", must(m.InstanceName()))
+	assert.Equal(t, "5678", must(m.InstanceID()))
+	assert.Equal(t, "web-1.c.my-project.internal", must(m.Meta("instance/hostname")))
+	assert.Equal(t, "web-1.c.my-project.internal", must(m.Meta("/instance/hostname")))
+	assert.Equal(t, "web", must(m.Attribute("role")))
+	assert.Equal(t, "default", must(m.Attribute("missing", "default")))
+	assert.Equal(t, "", must(m.Attribute("missing")))
+	assert.Equal(t, "sa@my-project.iam.gserviceaccount.com", must(m.ServiceAccount()))
+	assert.Equal(t, []string{
+		"https://www.googleapis.com/auth/devstorage.read_only",
+		"https://www.googleapis.com/auth/logging.write",
+	}, must(m.Scopes()))
+}
+
+func TestMeta_NotMetadataServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// nolint: errcheck
+		w.Write([]byte("some captive portal"))
+	}))
+	defer server.Close()
+	m := &Meta{Endpoint: server.URL, cache: make(map[string]string)}
+
+	assert.Equal(t, "default", must(m.ProjectID("default")))
+	assert.True(t, m.nonGCP)
+}
+
+func TestMeta_NonGCP(t *testing.T) {
+	m := NewMeta(ClientOptions{})
+	m.nonGCP = true
+
+	assert.Equal(t, "foo", must(m.ProjectID("foo")))
+	assert.Equal(t, "", must(m.Zone()))
+	assert.Equal(t, "bar", must(m.Region("bar")))
+	assert.Equal(t, []string{}, must(m.Scopes()))
+}
+
+func TestMeta_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	url := server.URL
+	server.Close()
+	m := &Meta{Endpoint: url, cache: make(map[string]string)}
+
+	assert.Equal(t, "foo", must(m.InstanceName("foo")))
+	assert.True(t, m.nonGCP)
+}
@@ -4,11 +4,14 @@ go 1.13
 
 require (
 	cloud.google.com/go/storage v1.6.0 // indirect
-	github.com/Masterminds/goutils v1.1.0
+	github.com/Masterminds/goutils v1.1.1
+	github.com/Masterminds/sprig/v3 v3.2.3
 	github.com/Shopify/ejson v1.2.1
+	github.com/andybalholm/brotli v1.0.5
 	github.com/armon/go-metrics v0.3.3 // indirect
 	github.com/aws/aws-sdk-go v1.30.19
 	github.com/boltdb/bolt v1.3.1
+	github.com/cespare/xxhash/v2 v2.1.2
 	github.com/docker/libkv v0.2.1
 	github.com/dustin/gojson v0.0.0-20160307161227-2e71ec9dd5ad // indirect
 	github.com/frankban/quicktest v1.9.0 // indirect
@@ -27,9 +30,11 @@ require (
 	github.com/hashicorp/vault/api v1.0.4
 	github.com/johannesboyne/gofakes3 v0.0.0-20200218152459-de0855a40bc1
 	github.com/joho/godotenv v1.3.0
+	github.com/klauspost/compress v1.18.0
 	github.com/niemeyer/pretty v0.0.0-20200227124842-a10e7caefd8e // indirect
 	github.com/pierrec/lz4 v2.5.0+incompatible // indirect
 	github.com/pkg/errors v0.9.1
+	github.com/rainycape/unidecode v0.0.0-20150907023854-cb7f23ec59be
 	github.com/rs/zerolog v1.18.0
 	github.com/sergi/go-diff v1.1.0 // indirect
 	github.com/smartystreets/goconvey v1.6.4 // indirect
@@ -37,10 +42,12 @@ require (
 	github.com/spf13/cobra v0.0.7
 	github.com/stretchr/testify v1.5.1
 	github.com/ugorji/go/codec v1.1.7
+	github.com/yuin/goldmark v1.4.12
 	github.com/zealic/xignore v0.3.3
+	github.com/zeebo/blake3 v0.2.3
 	gocloud.dev v0.19.0
 	golang.org/x/crypto v0.0.0-20200406173513-056763e48d71
-	golang.org/x/net v0.0.0-20200324143707-d3edc9973b7e // indirect
+	golang.org/x/net v0.0.0-20200324143707-d3edc9973b7e
 	golang.org/x/tools v0.0.0-20200410132612-ae9902aceb98 // indirect
 	google.golang.org/api v0.21.0 // indirect
 	google.golang.org/genproto v0.0.0-20200410110633-0848e9f44c36 // indirect
@@ -5,8 +5,10 @@ package gomplate
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
@@ -15,7 +17,9 @@ import (
 	"time"
 
 	"github.com/hairyhenderson/gomplate/v3/data"
+	"github.com/hairyhenderson/gomplate/v3/funcs"
 	"github.com/hairyhenderson/gomplate/v3/internal/config"
+	"github.com/hairyhenderson/gomplate/v3/tmpl"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/spf13/afero"
@@ -29,6 +33,14 @@ type gomplate struct {
 	nestedTemplates templateAliases
 	rootTemplate    *template.Template
 	tmplctx         interface{}
+	reader          tmpl.ReaderFunc
+
+	// remote nested templates, cached so they're only read once
+	nestedCache map[string][]byte
+
+	// the missingkey mode, and the value to print in "default" mode
+	missingKey        string
+	missingKeyDefault string
 }
 
 // runTemplate -
@@ -46,20 +58,45 @@ func (g *gomplate) runTemplate(_ context.Context, t *tplate) error {
 			defer t.target.(io.Closer).Close()
 		}
 	}
-	err = tmpl.Execute(t.target, g.tmplctx)
+	var w io.Writer = t.target
+	if g.missingKey == "default" {
+		w = &missingValueWriter{w: w, fallback: []byte(g.missingKeyDefault)}
+	}
+	err = tmpl.Execute(w, g.tmplctx)
 	return err
 }
 
+// missingValueWriter - replaces the "<no value>" printed for missing keys
+// with a fallback value. text/template writes it on its own, so there's no
+// need to look for it within larger writes.
+type missingValueWriter struct {
+	w        io.Writer
+	fallback []byte
+}
+
+var noValue = []byte("<no value>")
+
+func (m *missingValueWriter) Write(p []byte) (int, error) {
+	if !bytes.Equal(p, noValue) {
+		return m.w.Write(p)
+	}
+	if _, err := m.w.Write(m.fallback); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
 type templateAliases map[string]string
 
 // newGomplate -
-func newGomplate(funcMap template.FuncMap, leftDelim, rightDelim string, nested templateAliases, tctx interface{}) *gomplate {
+func newGomplate(funcMap template.FuncMap, leftDelim, rightDelim string, nested templateAliases, tctx interface{}, reader tmpl.ReaderFunc) *gomplate {
 	return &gomplate{
 		leftDelim:       leftDelim,
 		rightDelim:      rightDelim,
 		funcMap:         funcMap,
 		nestedTemplates: nested,
 		tmplctx:         tctx,
+		reader:          reader,
 	}
 }
 
@@ -83,6 +120,10 @@ func parseTemplateArg(templateArg string, ta templateAliases) error {
 		pth = parts[1]
 	}
 
+	if u, ok := templateURL(pth); ok {
+		return parseTemplateURL(u, alias, ta)
+	}
+
 	switch fi, err := fs.Stat(pth); {
 	case err != nil:
 		return err
@@ -110,6 +151,49 @@ func parseTemplateArg(templateArg string, ta templateAliases) error {
 	return nil
 }
 
+// templateURL - parse the nested template reference as a URL, if it is one
+// that must be read as a datasource. Paths, file: URLs, and single-letter
+// schemes (Windows drive letters) are read from the filesystem.
+func templateURL(ref string) (*url.URL, bool) {
+	u, err := url.Parse(ref)
+	if err != nil || len(u.Scheme) < 2 || u.Scheme == "file" {
+		return nil, false
+	}
+	return u, true
+}
+
+// parseTemplateURL - add aliases for a nested template (or directory of
+// templates) read from any datasource URL. Directory URLs must end with a
+// '/', and are only supported by datasources that can list directories.
+func parseTemplateURL(u *url.URL, alias string, ta templateAliases) error {
+	if alias == "" {
+		alias = u.String()
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		ta[alias] = u.String()
+		return nil
+	}
+
+	b, err := readURL(u, nil)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't list nested templates in %s", u)
+	}
+	names := []string{}
+	if err := json.Unmarshal(b, &names); err != nil {
+		return errors.Wrapf(err, "couldn't list nested templates in %s: not a directory listing", u)
+	}
+	for _, name := range names {
+		if strings.HasSuffix(name, "/") { // one-level only
+			continue
+		}
+		f := *u
+		f.Path += name
+		f.RawPath = ""
+		ta[path.Join(alias, name)] = f.String()
+	}
+	return nil
+}
+
 // RunTemplates - run all gomplate templates specified by the given configuration
 func RunTemplates(o *Config) error {
 	cfg, err := o.toNewConfig()
@@ -138,12 +222,28 @@ func RunTemplatesWithContext(ctx context.Context, cfg *config.Config) error {
 	if err != nil {
 		return err
 	}
+	c, err = applyValuesFiles(c, cfg.ValuesFiles, d)
+	if err != nil {
+		return err
+	}
+	c, err = applySetValues(c, cfg.SetValues, cfg.SetFiles)
+	if err != nil {
+		return err
+	}
 	funcMap := Funcs(d)
+	funcs.AddExecFuncs(ctx, funcMap, cfg.EnableExec)
 	err = bindPlugins(ctx, cfg, funcMap)
 	if err != nil {
 		return err
 	}
-	g := newGomplate(funcMap, cfg.LDelim, cfg.RDelim, nested, c)
+	if cfg.EnableSprig {
+		funcs.AddSprigFuncs(funcMap)
+	}
+	g := newGomplate(funcMap, cfg.LDelim, cfg.RDelim, nested, c, d.Include)
+	g.missingKey, g.missingKeyDefault = cfg.MissingKey, cfg.MissingKeyDefault
+	if g.missingKey == "" && g.missingKeyDefault != "" {
+		g.missingKey = "default"
+	}
 
 	return g.runTemplates(ctx, cfg)
 }
@@ -3,7 +3,10 @@ package gomplate
 import (
 	"bytes"
 	"context"
+	"errors"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
@@ -231,6 +234,88 @@ func TestParseTemplateArgs(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestParseTemplateURL(t *testing.T) {
+	defer func() { readURL = data.ReadURL }()
+	reads := []string{}
+	readURL = func(u *url.URL, _ http.Header) ([]byte, error) {
+		reads = append(reads, u.String())
+		switch u.String() {
+		case "git+https://example.com/lib.git//partials/":
+			return []byte(`["header","footer","sub/"]`), nil
+		case "https://example.com/partials/":
+			return []byte(`<html></html>`), nil
+		case "git+https://example.com/lib.git//partials/header":
+			return []byte(`hello {{ . }}`), nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	nested := templateAliases{}
+	err := parseTemplateArg("lib=git+https://example.com/lib.git//partials/", nested)
+	assert.NoError(t, err)
+	assert.Equal(t, templateAliases{
+		"lib/header": "git+https://example.com/lib.git//partials/header",
+		"lib/footer": "git+https://example.com/lib.git//partials/footer",
+	}, nested)
+
+	nested = templateAliases{}
+	err = parseTemplateArg("foo=https://example.com/foo.t", nested)
+	assert.NoError(t, err)
+	assert.Equal(t, templateAliases{"foo": "https://example.com/foo.t"}, nested)
+
+	nested = templateAliases{}
+	err = parseTemplateArg("https://example.com/foo.t", nested)
+	assert.NoError(t, err)
+	assert.Equal(t, templateAliases{"https://example.com/foo.t": "https://example.com/foo.t"}, nested)
+
+	err = parseTemplateArg("p=https://example.com/partials/", templateAliases{})
+	assert.Error(t, err)
+
+	err = parseTemplateArg("p=s3://bucket/missing/", templateAliases{})
+	assert.Error(t, err)
+
+	// remote templates are read once, no matter how many templates use them
+	reads = []string{}
+	g := newGomplate(template.FuncMap{}, "{{", "}}", templateAliases{
+		"lib/header": "git+https://example.com/lib.git//partials/header",
+	}, "world", nil)
+	assert.Equal(t, "hello world", testTemplate(g, `{{ template "lib/header" . }}`))
+	assert.Equal(t, "hello world", testTemplate(g, `{{ template "lib/header" . }}`))
+	assert.Equal(t, []string{"git+https://example.com/lib.git//partials/header"}, reads)
+}
+
+func TestMissingKey(t *testing.T) {
+	ctx := map[string]interface{}{"name": "world", "empty": nil}
+	render := func(mode, def, in string) (string, error) {
+		g := newGomplate(template.FuncMap{}, "{{", "}}", nil, ctx, nil)
+		g.missingKey, g.missingKeyDefault = mode, def
+		var out bytes.Buffer
+		err := g.runTemplate(context.TODO(), &tplate{name: "t", contents: in, target: &out})
+		return out.String(), err
+	}
+
+	_, err := render("", "", "{{ .bogus }}")
+	assert.Error(t, err)
+	_, err = render("error", "", "{{ .bogus }}")
+	assert.Error(t, err)
+
+	out, err := render("invalid", "", "hello {{ .name }} {{ .bogus }}")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world <no value>", out)
+
+	out, err = render("zero", "", "hello {{ .name }} {{ .bogus }}")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world <no value>", out)
+
+	out, err = render("default", "N/A", "hello {{ .name }} {{ .bogus }}, <no value> {{ .empty }}")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world N/A, <no value> N/A", out)
+
+	out, err = render("default", "", "[{{ .bogus }}]")
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", out)
+}
+
 func TestSimpleNamer(t *testing.T) {
 	n := simpleNamer("out/")
 	out, err := n("file")
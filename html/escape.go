@@ -0,0 +1,43 @@
+package html
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"text/template"
+)
+
+// Escape - escape the input for use in HTML text or in a quoted attribute
+// value
+func Escape(in string) string {
+	return html.EscapeString(in)
+}
+
+// Unescape - unescape HTML entities like "&lt;" and "&#39;"
+func Unescape(in string) string {
+	return html.UnescapeString(in)
+}
+
+// EscapeJS - escape the input for use in a JavaScript string literal, whether
+// in a <script> element or an event handler attribute
+func EscapeJS(in string) string {
+	return template.JSEscapeString(in)
+}
+
+// EscapeCSS - escape the input for use in a quoted CSS string, whether in a
+// <style> element or a style attribute. All characters other than ASCII
+// letters and digits are escaped as hexadecimal code points.
+func EscapeCSS(in string) string {
+	sb := &strings.Builder{}
+	for _, r := range in {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		default:
+			// the trailing space terminates the escape, and is consumed by
+			// the CSS parser
+			fmt.Fprintf(sb, "\\%x ", r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,196 @@
+// Package html contains functions for sanitizing and escaping HTML
+package html
+
+import (
+	"bytes"
+	"html"
+	"net/url"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// Policy - an allowlist of the elements and attributes to keep when
+// sanitizing HTML. Everything else is removed, though the text inside removed
+// elements is kept (except for elements like <script> and <style>, which are
+// removed entirely).
+type Policy struct {
+	// Elements - the allowed elements, with the attributes allowed on each
+	Elements map[string][]string
+	// URLSchemes - the schemes allowed in URL-valued attributes (href, src,
+	// cite). Relative URLs are always allowed.
+	URLSchemes []string
+	// NoFollow - add rel="nofollow" to links
+	NoFollow bool
+}
+
+// StrictPolicy - removes all elements, keeping only text
+var StrictPolicy = &Policy{}
+
+// UGCPolicy - allows the basic formatting, list, table, link, and image
+// elements that are commonly used in user-generated content, with no styling,
+// scripting, or forms. Links are given rel="nofollow".
+var UGCPolicy = &Policy{
+	Elements: map[string][]string{
+		"a":          {"href", "title"},
+		"abbr":       {"title"},
+		"b":          nil,
+		"blockquote": {"cite"},
+		"br":         nil,
+		"caption":    nil,
+		"code":       nil,
+		"dd":         nil,
+		"del":        nil,
+		"div":        nil,
+		"dl":         nil,
+		"dt":         nil,
+		"em":         nil,
+		"h1":         nil,
+		"h2":         nil,
+		"h3":         nil,
+		"h4":         nil,
+		"h5":         nil,
+		"h6":         nil,
+		"hr":         nil,
+		"i":          nil,
+		"img":        {"src", "alt", "title", "width", "height"},
+		"ins":        nil,
+		"kbd":        nil,
+		"li":         nil,
+		"ol":         {"start"},
+		"p":          nil,
+		"pre":        nil,
+		"q":          {"cite"},
+		"s":          nil,
+		"samp":       nil,
+		"small":      nil,
+		"span":       nil,
+		"strike":     nil,
+		"strong":     nil,
+		"sub":        nil,
+		"sup":        nil,
+		"table":      nil,
+		"tbody":      nil,
+		"td":         {"colspan", "rowspan"},
+		"tfoot":      nil,
+		"th":         {"colspan", "rowspan", "scope"},
+		"thead":      nil,
+		"tr":         nil,
+		"u":          nil,
+		"ul":         nil,
+	},
+	URLSchemes: []string{"http", "https", "mailto"},
+	NoFollow:   true,
+}
+
+// elements whose content is removed along with the element itself
+var dropContent = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"iframe":   true,
+	"noscript": true,
+	"noembed":  true,
+	"noframes": true,
+	"object":   true,
+	"template": true,
+	"textarea": true,
+	"title":    true,
+	"xmp":      true,
+}
+
+var urlAttrs = map[string]bool{
+	"href": true,
+	"src":  true,
+	"cite": true,
+}
+
+// Sanitize - remove everything from the input that isn't allowed by the
+// policy. Text is always escaped, so the output is safe to include in an
+// HTML document.
+func (p *Policy) Sanitize(in string) string {
+	out := &bytes.Buffer{}
+	z := xhtml.NewTokenizer(strings.NewReader(in))
+	skip := ""
+	for {
+		tt := z.Next()
+		if tt == xhtml.ErrorToken {
+			// we're reading from a string, so this can only be io.EOF
+			return out.String()
+		}
+		t := z.Token()
+		if skip != "" {
+			if tt == xhtml.EndTagToken && t.Data == skip {
+				skip = ""
+			}
+			continue
+		}
+		switch tt {
+		case xhtml.TextToken:
+			out.WriteString(html.EscapeString(t.Data))
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			if dropContent[t.Data] {
+				if tt == xhtml.StartTagToken {
+					skip = t.Data
+				}
+				continue
+			}
+			allowed, ok := p.Elements[t.Data]
+			if !ok {
+				continue
+			}
+			out.WriteString("<" + t.Data)
+			p.writeAttrs(out, t, allowed)
+			if tt == xhtml.SelfClosingTagToken {
+				out.WriteString("/")
+			}
+			out.WriteString(">")
+		case xhtml.EndTagToken:
+			if _, ok := p.Elements[t.Data]; ok {
+				out.WriteString("</" + t.Data + ">")
+			}
+		}
+	}
+}
+
+func (p *Policy) writeAttrs(out *bytes.Buffer, t xhtml.Token, allowed []string) {
+	hasHref := false
+	for _, a := range t.Attr {
+		if a.Namespace != "" || !contains(allowed, a.Key) {
+			continue
+		}
+		if urlAttrs[a.Key] {
+			if !p.allowedURL(a.Val) {
+				continue
+			}
+			if a.Key == "href" {
+				hasHref = true
+			}
+		}
+		out.WriteString(" " + a.Key + `="` + html.EscapeString(a.Val) + `"`)
+	}
+	if p.NoFollow && t.Data == "a" && hasHref {
+		out.WriteString(` rel="nofollow"`)
+	}
+}
+
+func (p *Policy) allowedURL(s string) bool {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		// relative URLs are fine, but not protocol-relative ones like
+		// "//example.com", which could point anywhere
+		return u.Host == ""
+	}
+	return contains(p.URLSchemes, strings.ToLower(u.Scheme))
+}
+
+func contains(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
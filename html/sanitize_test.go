@@ -0,0 +1,51 @@
+package html
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeUGC(t *testing.T) {
+	data := []struct {
+		in, expected string
+	}{
+		{"", ""},
+		{"plain text", "plain text"},
+		{"1 < 2 & 3 > 2", "1 &lt; 2 &amp; 3 &gt; 2"},
+		{"<p>Hello, <b>world</b>!</p>", "<p>Hello, <b>world</b>!</p>"},
+		{`<p class="x" onclick="alert(1)">hi</p>`, "<p>hi</p>"},
+		{"<script>alert(1)</script>ok", "ok"},
+		{"<style>body{display:none}</style>ok", "ok"},
+		{"<iframe src=x>nested <b>stuff</b></iframe>ok", "ok"},
+		{"<blink>text</blink>", "text"},
+		{"<!-- comment -->text", "text"},
+		{"line<br/>break", "line<br/>break"},
+		{`<a href="https://example.com/?a=1&amp;b=2" target="_blank">link</a>`, `<a href="https://example.com/?a=1&amp;b=2" rel="nofollow">link</a>`},
+		{`<a href="/relative">link</a>`, `<a href="/relative" rel="nofollow">link</a>`},
+		{`<a href="javascript:alert(1)">link</a>`, `<a>link</a>`},
+		{`<a href="JavaScript&#58;alert(1)">link</a>`, `<a>link</a>`},
+		{`<a href="java	script:alert(1)">link</a>`, `<a>link</a>`},
+		{`<a href="//evil.example.com">link</a>`, `<a>link</a>`},
+		{`<img src="data:image/png;base64,AAAA" alt="a &quot;pic&quot;">`, `<img alt="a &#34;pic&#34;">`},
+		{`<img src="https://example.com/x.png" onerror="alert(1)">`, `<img src="https://example.com/x.png">`},
+		{`<svg><script>alert(1)</script></svg>`, ``},
+	}
+	for _, d := range data {
+		assert.Equal(t, d.expected, UGCPolicy.Sanitize(d.in), d.in)
+	}
+}
+
+func TestSanitizeStrict(t *testing.T) {
+	assert.Equal(t, "Hello, world!", StrictPolicy.Sanitize(`<p>Hello, <a href="https://example.com">world</a>!</p>`))
+	assert.Equal(t, "ok", StrictPolicy.Sanitize("<script>alert(1)</script>ok"))
+	assert.Equal(t, "&lt;b&gt;", StrictPolicy.Sanitize("&lt;b&gt;"))
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, "&lt;a href=&#34;x&#34;&gt;Tom &amp; Jerry&#39;s&lt;/a&gt;", Escape(`<a href="x">Tom & Jerry's</a>`))
+	assert.Equal(t, `<a href="x">Tom & Jerry's</a>`, Unescape("&lt;a href=&quot;x&quot;&gt;Tom &amp; Jerry&#39;s&lt;/a&gt;"))
+	assert.Equal(t, `it\'s \"quoted\" \u003C/script\u003E`, EscapeJS(`it's "quoted" </script>`))
+	assert.Equal(t, `a\20 b\22 \3c \2f style\3e `, EscapeCSS(`a b"</style>`))
+	assert.Equal(t, `caf\e9 `, EscapeCSS("café"))
+}
@@ -0,0 +1,262 @@
+// Package i18n contains functions for translating messages with gettext-style
+// message catalogs
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Catalog - a set of translated messages, keyed by message ID (the
+// untranslated message)
+type Catalog struct {
+	// messages - the translations for each message ID - one for singular
+	// messages, or one per plural form for plural messages
+	messages map[string][]string
+	nplurals int
+	plural   pluralFunc
+}
+
+func newCatalog() *Catalog {
+	return &Catalog{
+		messages: map[string][]string{},
+		nplurals: 2,
+		plural:   defaultPlural,
+	}
+}
+
+// Parse - parse a message catalog in either gettext PO or JSON format,
+// depending on whether it looks like a JSON object
+func Parse(in []byte) (*Catalog, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(in), []byte("{")) {
+		return ParseJSON(in)
+	}
+	return ParsePO(in)
+}
+
+// ParseJSON - parse a JSON message catalog. This is an object mapping message
+// IDs to translations, which are either strings or (for plural messages)
+// arrays of strings, one per plural form. As in PO files, the translation of
+// the empty message ID is the catalog's header, which may contain a
+// Plural-Forms line.
+func ParseJSON(in []byte) (*Catalog, error) {
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(in, &m); err != nil {
+		return nil, errors.Wrap(err, "failed to parse JSON message catalog")
+	}
+	c := newCatalog()
+	for k, v := range m {
+		switch t := v.(type) {
+		case string:
+			c.messages[k] = []string{t}
+		case []interface{}:
+			forms := make([]string, len(t))
+			for i, f := range t {
+				s, ok := f.(string)
+				if !ok {
+					return nil, errors.Errorf("invalid translation for %q: plural forms must be strings, got %T", k, f)
+				}
+				forms[i] = s
+			}
+			c.messages[k] = forms
+		default:
+			return nil, errors.Errorf("invalid translation for %q: must be a string or an array of strings, got %T", k, v)
+		}
+	}
+	if err := c.parseHeader(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// poEntry - a single entry in a PO file, while it's being parsed
+type poEntry struct {
+	ctxt, id, idPlural *string
+	strs               map[int]*string
+	fuzzy              bool
+}
+
+// ParsePO - parse a gettext PO file. Fuzzy and untranslated entries are
+// ignored, as are entries with a message context (msgctxt).
+func ParsePO(in []byte) (*Catalog, error) {
+	c := newCatalog()
+	e := &poEntry{strs: map[int]*string{}}
+	// the string currently being continued by quoted lines
+	var cur *string
+
+	flush := func() {
+		if e.id != nil && e.ctxt == nil && !e.fuzzy {
+			c.addPO(e)
+		}
+		e = &poEntry{strs: map[int]*string{}}
+		cur = nil
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(in))
+	lineNo := 0
+	for s.Scan() {
+		lineNo++
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#,"):
+			if e.id != nil && len(e.strs) > 0 {
+				flush()
+			}
+			for _, flag := range strings.Split(line[2:], ",") {
+				if strings.TrimSpace(flag) == "fuzzy" {
+					e.fuzzy = true
+				}
+			}
+		case strings.HasPrefix(line, "#"):
+			// other comments are ignored
+		case strings.HasPrefix(line, `"`):
+			if cur == nil {
+				return nil, errors.Errorf("line %d: unexpected string", lineNo)
+			}
+			v, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d: invalid string %s", lineNo, line)
+			}
+			*cur += v
+		default:
+			kw, v, err := parsePOLine(line)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d", lineNo)
+			}
+			switch {
+			case kw == "msgctxt":
+				if e.id != nil {
+					flush()
+				}
+				e.ctxt = &v
+				cur = e.ctxt
+			case kw == "msgid":
+				if e.id != nil {
+					flush()
+				}
+				e.id = &v
+				cur = e.id
+			case kw == "msgid_plural":
+				e.idPlural = &v
+				cur = e.idPlural
+			case kw == "msgstr":
+				e.strs[0] = &v
+				cur = e.strs[0]
+			case strings.HasPrefix(kw, "msgstr[") && strings.HasSuffix(kw, "]"):
+				i, err := strconv.Atoi(kw[len("msgstr[") : len(kw)-1])
+				if err != nil || i < 0 {
+					return nil, errors.Errorf("line %d: invalid keyword %q", lineNo, kw)
+				}
+				e.strs[i] = &v
+				cur = e.strs[i]
+			default:
+				return nil, errors.Errorf("line %d: unknown keyword %q", lineNo, kw)
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	if err := c.parseHeader(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func parsePOLine(line string) (keyword, value string, err error) {
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) != 2 {
+		return "", "", errors.Errorf("invalid line %q", line)
+	}
+	value, err = strconv.Unquote(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return "", "", errors.Wrapf(err, "invalid string %s", parts[1])
+	}
+	return parts[0], value, nil
+}
+
+// addPO - add a parsed PO entry, unless it's untranslated
+func (c *Catalog) addPO(e *poEntry) {
+	forms := make([]string, len(e.strs))
+	for i := range forms {
+		s, ok := e.strs[i]
+		if !ok || *s == "" {
+			return
+		}
+		forms[i] = *s
+	}
+	if len(forms) == 0 {
+		return
+	}
+	c.messages[*e.id] = forms
+}
+
+// parseHeader - read the Plural-Forms header, if present
+func (c *Catalog) parseHeader() error {
+	hdr, ok := c.messages[""]
+	if !ok {
+		return nil
+	}
+	delete(c.messages, "")
+	for _, line := range strings.Split(hdr[0], "\n") {
+		kv := strings.SplitN(line, ":", 2)
+		if len(kv) == 2 && strings.EqualFold(strings.TrimSpace(kv[0]), "Plural-Forms") {
+			nplurals, f, err := parsePluralForms(kv[1])
+			if err != nil {
+				return err
+			}
+			c.nplurals, c.plural = nplurals, f
+		}
+	}
+	return nil
+}
+
+// Translate - translate the message, returning the message ID itself when
+// there's no translation. The result is used as a format string when args
+// are given.
+func (c *Catalog) Translate(id string, args ...interface{}) string {
+	msg := id
+	if c != nil {
+		if forms, ok := c.messages[id]; ok {
+			msg = forms[0]
+		}
+	}
+	return format(msg, args)
+}
+
+// TranslatePlural - translate the message, choosing the plural form
+// appropriate for n. When there's no translation, id is used when n is 1,
+// and plural otherwise. The result is used as a format string when args are
+// given.
+func (c *Catalog) TranslatePlural(id, plural string, n int, args ...interface{}) string {
+	msg := plural
+	if n == 1 {
+		msg = id
+	}
+	if c != nil {
+		if forms, ok := c.messages[id]; ok {
+			i := c.plural(n)
+			if i >= 0 && i < len(forms) && i < c.nplurals {
+				msg = forms[i]
+			}
+		}
+	}
+	return format(msg, args)
+}
+
+func format(msg string, args []interface{}) string {
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
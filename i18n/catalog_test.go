@@ -0,0 +1,131 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPO = `# French translations
+msgid ""
+msgstr ""
+"Language: fr\n"
+"Plural-Forms: nplurals=2; plural=(n > 1);\n"
+
+#: templates/motd.tmpl:1
+msgid "Hello, %s!"
+msgstr "Bonjour, %s !"
+
+msgid "long"
+msgstr ""
+"une ligne "
+"plus longue"
+
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] "%d fichier"
+msgstr[1] "%d fichiers"
+
+#, fuzzy
+msgid "fuzzy"
+msgstr "flou"
+
+msgid "untranslated"
+msgstr ""
+
+msgctxt "menu"
+msgid "File"
+msgstr "Fichier"
+msgid "escaped"
+msgstr "\"quoted\"\ttab"
+`
+
+func TestParsePO(t *testing.T) {
+	c, err := ParsePO([]byte(testPO))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Bonjour, Jane !", c.Translate("Hello, %s!", "Jane"))
+	assert.Equal(t, "une ligne plus longue", c.Translate("long"))
+	assert.Equal(t, `"quoted"	tab`, c.Translate("escaped"))
+	assert.Equal(t, "fuzzy", c.Translate("fuzzy"))
+	assert.Equal(t, "untranslated", c.Translate("untranslated"))
+	assert.Equal(t, "File", c.Translate("File"))
+	assert.Equal(t, "missing", c.Translate("missing"))
+
+	// French uses the singular for 0
+	assert.Equal(t, "0 fichier", c.TranslatePlural("%d file", "%d files", 0, 0))
+	assert.Equal(t, "1 fichier", c.TranslatePlural("%d file", "%d files", 1, 1))
+	assert.Equal(t, "2 fichiers", c.TranslatePlural("%d file", "%d files", 2, 2))
+	assert.Equal(t, "2 dogs", c.TranslatePlural("%d dog", "%d dogs", 2, 2))
+	assert.Equal(t, "1 dog", c.TranslatePlural("%d dog", "%d dogs", 1, 1))
+
+	_, err = ParsePO([]byte(`msgid "x`))
+	assert.Error(t, err)
+
+	_, err = ParsePO([]byte(`"orphan"`))
+	assert.Error(t, err)
+
+	_, err = ParsePO([]byte(`msgfoo "x"`))
+	assert.Error(t, err)
+
+	_, err = ParsePO([]byte("msgid \"\"\nmsgstr \"Plural-Forms: nplurals=2; plural=(n >;\\n\"\n"))
+	assert.Error(t, err)
+}
+
+func TestParseJSON(t *testing.T) {
+	c, err := Parse([]byte(`{
+		"": "Plural-Forms: nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+		"Hello": "Привет",
+		"%d file": ["%d файл", "%d файла", "%d файлов"]
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "Привет", c.Translate("Hello"))
+	assert.Equal(t, "1 файл", c.TranslatePlural("%d file", "%d files", 1, 1))
+	assert.Equal(t, "3 файла", c.TranslatePlural("%d file", "%d files", 3, 3))
+	assert.Equal(t, "5 файлов", c.TranslatePlural("%d file", "%d files", 5, 5))
+	assert.Equal(t, "21 файл", c.TranslatePlural("%d file", "%d files", 21, 21))
+	assert.Equal(t, "11 файлов", c.TranslatePlural("%d file", "%d files", 11, 11))
+
+	_, err = ParseJSON([]byte(`{"x": 1}`))
+	assert.Error(t, err)
+
+	_, err = ParseJSON([]byte(`{"x": [1]}`))
+	assert.Error(t, err)
+
+	_, err = ParseJSON([]byte(`{`))
+	assert.Error(t, err)
+}
+
+func TestNilCatalog(t *testing.T) {
+	var c *Catalog
+	assert.Equal(t, "Hello, Jane", c.Translate("Hello, %s", "Jane"))
+	assert.Equal(t, "2 files", c.TranslatePlural("%d file", "%d files", 2, 2))
+}
+
+func TestParsePluralExpr(t *testing.T) {
+	data := []struct {
+		expr     string
+		expected []int // for n = 0, 1, 2, 5, 11, 21, 102
+	}{
+		{"0", []int{0, 0, 0, 0, 0, 0, 0}},
+		{"n != 1", []int{1, 0, 1, 1, 1, 1, 1}},
+		{"(n > 1)", []int{0, 0, 1, 1, 1, 1, 1}},
+		{"n==1 ? 0 : n==2 ? 1 : 2", []int{2, 0, 1, 2, 2, 2, 2}},
+		{"!(n % 10 == 1)", []int{1, 0, 1, 1, 0, 0, 1}},
+		{"n%100/10 + n*2 - 1", []int{-1, 1, 3, 9, 22, 43, 203}},
+		{"n >= 2 && n <= 5 || n == 0", []int{1, 0, 1, 1, 0, 0, 0}},
+		{"n % 0 + n / 0", []int{0, 0, 0, 0, 0, 0, 0}},
+	}
+	for _, d := range data {
+		f, err := parsePluralExpr(d.expr)
+		assert.NoError(t, err, d.expr)
+		for i, n := range []int{0, 1, 2, 5, 11, 21, 102} {
+			assert.Equal(t, d.expected[i], f(n), "%s with n=%d", d.expr, n)
+		}
+	}
+
+	for _, expr := range []string{"", "n +", "(n", "n ? 1", "x", "n 1"} {
+		_, err := parsePluralExpr(expr)
+		assert.Error(t, err, expr)
+	}
+}
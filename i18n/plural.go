@@ -0,0 +1,241 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// pluralFunc - returns the index of the plural form to use for n
+type pluralFunc func(n int) int
+
+// defaultPlural - the English (and Germanic) rule, used when the catalog
+// doesn't specify one
+func defaultPlural(n int) int {
+	if n != 1 {
+		return 1
+	}
+	return 0
+}
+
+// parsePluralForms - parse a gettext Plural-Forms header value, like
+// "nplurals=2; plural=(n != 1);"
+func parsePluralForms(s string) (nplurals int, f pluralFunc, err error) {
+	expr := ""
+	for _, part := range strings.Split(s, ";") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch strings.TrimSpace(kv[0]) {
+		case "nplurals":
+			nplurals, err = strconv.Atoi(strings.TrimSpace(kv[1]))
+			if err != nil {
+				return 0, nil, errors.Wrapf(err, "invalid nplurals in Plural-Forms %q", s)
+			}
+		case "plural":
+			expr = kv[1]
+		}
+	}
+	if nplurals < 1 || expr == "" {
+		return 0, nil, errors.Errorf("invalid Plural-Forms %q", s)
+	}
+	f, err = parsePluralExpr(expr)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "invalid Plural-Forms %q", s)
+	}
+	return nplurals, f, nil
+}
+
+// parsePluralExpr - compile a C-like plural expression (in terms of n) into a
+// function
+func parsePluralExpr(expr string) (pluralFunc, error) {
+	p := &pluralParser{s: expr}
+	f, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return nil, errors.Errorf("unexpected %q at position %d", p.s[p.pos:], p.pos)
+	}
+	return f, nil
+}
+
+type pluralParser struct {
+	s   string
+	pos int
+}
+
+func (p *pluralParser) skipSpace() {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// accept - consume the first of the given operators found at the current
+// position
+func (p *pluralParser) accept(ops ...string) string {
+	p.skipSpace()
+	for _, op := range ops {
+		if strings.HasPrefix(p.s[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *pluralParser) ternary() (pluralFunc, error) {
+	cond, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.accept("?") == "" {
+		return cond, nil
+	}
+	a, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if p.accept(":") == "" {
+		return nil, errors.Errorf("expected ':' at position %d", p.pos)
+	}
+	b, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	return func(n int) int {
+		if cond(n) != 0 {
+			return a(n)
+		}
+		return b(n)
+	}, nil
+}
+
+// binary operators, by increasing precedence. Longer operators are listed
+// before their prefixes.
+var pluralOps = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *pluralParser) binary(level int) (pluralFunc, error) {
+	if level == len(pluralOps) {
+		return p.unary()
+	}
+	l, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.accept(pluralOps[level]...)
+		if op == "" {
+			return l, nil
+		}
+		r, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		l = binaryOp(op, l, r)
+	}
+}
+
+func binaryOp(op string, l, r pluralFunc) pluralFunc {
+	b := func(v bool) int {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	return func(n int) int {
+		x, y := l(n), r(n)
+		switch op {
+		case "||":
+			return b(x != 0 || y != 0)
+		case "&&":
+			return b(x != 0 && y != 0)
+		case "==":
+			return b(x == y)
+		case "!=":
+			return b(x != y)
+		case "<=":
+			return b(x <= y)
+		case ">=":
+			return b(x >= y)
+		case "<":
+			return b(x < y)
+		case ">":
+			return b(x > y)
+		case "+":
+			return x + y
+		case "-":
+			return x - y
+		case "*":
+			return x * y
+		case "/":
+			if y == 0 {
+				return 0
+			}
+			return x / y
+		default: // "%"
+			if y == 0 {
+				return 0
+			}
+			return x % y
+		}
+	}
+}
+
+func (p *pluralParser) unary() (pluralFunc, error) {
+	if p.accept("!") != "" {
+		f, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int) int {
+			if f(n) == 0 {
+				return 1
+			}
+			return 0
+		}, nil
+	}
+	return p.primary()
+}
+
+func (p *pluralParser) primary() (pluralFunc, error) {
+	if p.accept("(") != "" {
+		f, err := p.ternary()
+		if err != nil {
+			return nil, err
+		}
+		if p.accept(")") == "" {
+			return nil, errors.Errorf("expected ')' at position %d", p.pos)
+		}
+		return f, nil
+	}
+	if p.accept("n") != "" {
+		return func(n int) int { return n }, nil
+	}
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		if p.pos == len(p.s) {
+			return nil, errors.New("unexpected end of expression")
+		}
+		return nil, errors.Errorf("unexpected %q at position %d", p.s[p.pos:], p.pos)
+	}
+	v, err := strconv.Atoi(p.s[start:p.pos])
+	if err != nil {
+		return nil, err
+	}
+	return func(int) int { return v }, nil
+}
@@ -4,15 +4,20 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hairyhenderson/gomplate/v3/env"
+	"github.com/hairyhenderson/gomplate/v3/internal/glob"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 )
@@ -22,15 +27,104 @@ var (
 	PluginTimeoutKey = struct{}{}
 )
 
-// Parse a config file
+// Parse a config file. The format (YAML, JSON, or TOML) is detected from the
+// content.
+//
+// Environment variable references in the form ${VAR} (optionally with a
+// default, as in ${VAR:-default}) are expanded in all values before decoding.
 func Parse(in io.Reader) (*Config, error) {
+	return ParseFormat(in, "")
+}
+
+// ParseFormat - parse a config file in the given format - one of "yaml",
+// "json", or "toml". If the format is empty, it's detected from the content.
+// See also FormatFromName.
+func ParseFormat(in io.Reader, format string) (*Config, error) {
+	return parse(in, format, false)
+}
+
+// ParseStrict - like ParseFormat, but keys that don't correspond to any
+// setting (such as misspelled or wrongly-cased keys) are rejected, rather than
+// being silently ignored.
+func ParseStrict(in io.Reader, format string) (*Config, error) {
+	return parse(in, format, true)
+}
+
+func parse(in io.Reader, format string, strict bool) (*Config, error) {
 	out := &Config{}
-	dec := yaml.NewDecoder(in)
-	err := dec.Decode(out)
-	if err != nil && err != io.EOF {
+	b, err := ioutil.ReadAll(in)
+	if err != nil {
+		return out, err
+	}
+	if format == "" {
+		format = sniffFormat(b)
+	}
+
+	node := &yaml.Node{}
+	switch format {
+	case "yaml", "json":
+		// JSON is a subset of YAML, so the YAML decoder handles both
+		err = yaml.Unmarshal(b, node)
+	case "toml":
+		node, err = tomlNode(b)
+	default:
+		return out, fmt.Errorf("unsupported config file format %q", format)
+	}
+	if err != nil {
+		return out, err
+	}
+	if node.Kind == 0 {
+		// empty document
+		return out, nil
+	}
+
+	if strict {
+		// line numbers aren't meaningful for TOML, since it's been converted
+		err = checkKeys(node, reflect.TypeOf(Config{}), format != "toml")
+		if err != nil {
+			return out, err
+		}
+	}
+
+	err = expandEnvNode(node)
+	if err != nil {
+		return out, err
+	}
+	err = node.Decode(out)
+	if err != nil {
 		return out, err
 	}
-	return out, nil
+	err = out.applyConditions()
+	return out, err
+}
+
+// expandEnvNode - expand environment variable references in the scalar values
+// of the YAML document. Mapping keys are left alone.
+func expandEnvNode(n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if !strings.Contains(n.Value, "${") {
+			return nil
+		}
+		v, err := env.ExpandBraced(n.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", n.Line, err)
+		}
+		n.Value = v
+	case yaml.MappingNode:
+		for i := 1; i < len(n.Content); i += 2 {
+			if err := expandEnvNode(n.Content[i]); err != nil {
+				return err
+			}
+		}
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, c := range n.Content {
+			if err := expandEnvNode(c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // Config -
@@ -46,15 +140,47 @@ type Config struct {
 	SuppressEmpty bool     `yaml:"suppressEmpty,omitempty"`
 	ExecPipe      bool     `yaml:"execPipe,omitempty"`
 	PostExec      []string `yaml:"postExec,omitempty,flow"`
+	// the post-exec command's working directory and extra environment
+	// variables, and whether to run it as a shell command
+	PostExecDir   string            `yaml:"postExecDir,omitempty"`
+	PostExecEnv   map[string]string `yaml:"postExecEnv,omitempty"`
+	PostExecShell bool              `yaml:"postExecShell,omitempty"`
+
+	OutMode       string                  `yaml:"chmod,omitempty"`
+	LDelim        string                  `yaml:"leftDelim,omitempty"`
+	RDelim        string                  `yaml:"rightDelim,omitempty"`
+	Delims        []DelimConfig           `yaml:"delims,omitempty"`
+	DataSources   DSources                `yaml:"datasources,omitempty"`
+	Context       DSources                `yaml:"context,omitempty"`
+	Plugins       map[string]PluginConfig `yaml:"plugins,omitempty"`
+	PluginTimeout time.Duration           `yaml:"pluginTimeout,omitempty"`
+	EnableExec    bool                    `yaml:"enableExec,omitempty"`
+	EnableSprig   bool                    `yaml:"enableSprig,omitempty"`
+	Templates     []string                `yaml:"templates,omitempty"`
+
+	// What to do when a template references a missing map key - one of
+	// "error" (the default), "zero", "invalid", or "default", which prints
+	// MissingKeyDefault instead
+	MissingKey        string `yaml:"missingKey,omitempty"`
+	MissingKeyDefault string `yaml:"missingKeyDefault,omitempty"`
+
+	// Datasources to deep-merge into the root context, in order
+	ValuesFiles []string `yaml:"values,omitempty"`
+
+	// Values to deep-merge into the context, in key.path=value form, and
+	// files to read values from, in key.path=file form
+	SetValues []string `yaml:"set,omitempty"`
+	SetFiles  []string `yaml:"setFiles,omitempty"`
+
+	// Other config files to merge in, before this one
+	Include []string `yaml:"include,omitempty"`
 
-	OutMode       string            `yaml:"chmod,omitempty"`
-	LDelim        string            `yaml:"leftDelim,omitempty"`
-	RDelim        string            `yaml:"rightDelim,omitempty"`
-	DataSources   DSources          `yaml:"datasources,omitempty"`
-	Context       DSources          `yaml:"context,omitempty"`
-	Plugins       map[string]string `yaml:"plugins,omitempty"`
-	PluginTimeout time.Duration     `yaml:"pluginTimeout,omitempty"`
-	Templates     []string          `yaml:"templates,omitempty"`
+	// Named sets of overrides, selected with ApplyProfile
+	Profiles map[string]*Config `yaml:"profiles,omitempty"`
+
+	// When - a condition (see evalCondition) under which a profile is applied
+	// automatically. Only valid in profiles.
+	When string `yaml:"when,omitempty"`
 
 	// Extra HTTP headers not attached to pre-defined datsources. Potentially
 	// used by datasources defined in the template.
@@ -69,6 +195,9 @@ type Config struct {
 type DSources map[string]DSConfig
 
 func (d DSources) mergeFrom(o DSources) DSources {
+	if d == nil && len(o) > 0 {
+		d = DSources{}
+	}
 	for k, v := range o {
 		c, ok := d[k]
 		if ok {
@@ -84,45 +213,192 @@ func (d DSources) mergeFrom(o DSources) DSources {
 type DSConfig struct {
 	URL    *url.URL    `yaml:"-"`
 	Header http.Header `yaml:"header,omitempty,flow"`
+
+	// Type - the MIME type, overriding the type otherwise determined from the
+	// URL or the response
+	Type string `yaml:"type,omitempty"`
+	// Timeout - for reading the datasource (HTTP-based datasources only)
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// Retries - how many times to retry failed reads
+	Retries int `yaml:"retries,omitempty"`
+	// Auth - credentials (HTTP-based datasources only)
+	Auth *DSAuth `yaml:"auth,omitempty"`
+	// CacheTTL - how long to cache the datasource's contents for. By default
+	// they're cached until gomplate exits.
+	CacheTTL time.Duration `yaml:"cacheTTL,omitempty"`
+	// Optional - when set, a datasource that can't be read evaluates to
+	// Default rather than failing the render
+	Optional bool        `yaml:"optional,omitempty"`
+	Default  interface{} `yaml:"default,omitempty"`
+	// When - a condition (see evalCondition) which must be true for the
+	// datasource to be defined
+	When string `yaml:"when,omitempty"`
+}
+
+// PluginConfig - configuration for a plugin. In YAML it can be just the
+// command, or a map with the command and other options.
+type PluginConfig struct {
+	// Cmd - the command (or script) to run
+	Cmd string `yaml:"cmd,omitempty"`
+	// Args - fixed arguments, given before the arguments from the template
+	Args []string `yaml:"args,omitempty,flow"`
+	// Timeout - overrides PluginTimeout for this plugin
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// Env - names of environment variables to pass to the plugin. When nil,
+	// the whole environment is passed.
+	Env []string `yaml:"env,omitempty,flow"`
+	// Stderr - what to do with the plugin's standard error - "inherit" (the
+	// default) to write it to gomplate's stderr, "discard", or "capture" to
+	// include it in the error when the plugin fails
+	Stderr string `yaml:"stderr,omitempty"`
+	// When - a condition (see evalCondition) which must be true for the
+	// plugin to be defined
+	When string `yaml:"when,omitempty"`
+}
+
+type pluginConfigRaw PluginConfig
+
+// UnmarshalYAML - satisfy the yaml.Umarshaler interface - plugins can be
+// just the command, as a string
+func (p *PluginConfig) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*p = PluginConfig{Cmd: value.Value}
+		return nil
+	}
+	r := pluginConfigRaw{}
+	if err := value.Decode(&r); err != nil {
+		return err
+	}
+	*p = PluginConfig(r)
+	return nil
+}
+
+// MarshalYAML - satisfy the yaml.Marshaler interface - plugins with no
+// options other than the command are marshaled as just the command
+func (p PluginConfig) MarshalYAML() (interface{}, error) {
+	if p.Args == nil && p.Timeout == 0 && p.Env == nil && p.Stderr == "" && p.When == "" {
+		return p.Cmd, nil
+	}
+	// an empty (non-nil) Env is significant, so shouldn't be omitted
+	var env interface{}
+	if p.Env != nil {
+		env = p.Env
+	}
+	return struct {
+		Cmd     string        `yaml:"cmd,omitempty"`
+		Args    []string      `yaml:"args,omitempty,flow"`
+		Timeout time.Duration `yaml:"timeout,omitempty"`
+		Env     interface{}   `yaml:"env,omitempty,flow"`
+		Stderr  string        `yaml:"stderr,omitempty"`
+		When    string        `yaml:"when,omitempty"`
+	}{p.Cmd, p.Args, p.Timeout, env, p.Stderr, p.When}, nil
+}
+
+// DelimConfig - template delimiters for inputs matching Pattern, overriding
+// LDelim and RDelim. A pattern without a '/' matches the file's base name,
+// otherwise it matches the path relative to the input directory (or the
+// input file's path), with the syntax supported by glob.Match.
+type DelimConfig struct {
+	Pattern string `yaml:"pattern"`
+	LDelim  string `yaml:"leftDelim,omitempty"`
+	RDelim  string `yaml:"rightDelim,omitempty"`
+}
+
+// Matches - whether the input's name matches the pattern
+func (d DelimConfig) Matches(name string) bool {
+	name = filepath.ToSlash(filepath.Clean(name))
+	if !strings.Contains(d.Pattern, "/") {
+		name = path.Base(name)
+	}
+	// bad patterns are caught in Validate
+	ok, _ := glob.Match(d.Pattern, name)
+	return ok
+}
+
+// DSAuth - datasource credentials - either a username and password (for basic
+// auth) or a bearer token
+type DSAuth struct {
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+	Token    string `yaml:"token,omitempty"`
+}
+
+// dsConfigRaw - DSConfig with the URL as a string
+type dsConfigRaw struct {
+	URL      string        `yaml:"url,omitempty"`
+	Header   http.Header   `yaml:"header,omitempty,flow"`
+	Type     string        `yaml:"type,omitempty"`
+	Timeout  time.Duration `yaml:"timeout,omitempty"`
+	Retries  int           `yaml:"retries,omitempty"`
+	Auth     *DSAuth       `yaml:"auth,omitempty"`
+	CacheTTL time.Duration `yaml:"cacheTTL,omitempty"`
+	Optional bool          `yaml:"optional,omitempty"`
+	Default  interface{}   `yaml:"default,omitempty"`
+	When     string        `yaml:"when,omitempty"`
 }
 
 // UnmarshalYAML - satisfy the yaml.Umarshaler interface - URLs aren't
 // well supported, and anyway we need to do some extra parsing
 func (d *DSConfig) UnmarshalYAML(value *yaml.Node) error {
-	type raw struct {
-		URL    string
-		Header http.Header
-	}
-	r := raw{}
+	r := dsConfigRaw{}
 	err := value.Decode(&r)
 	if err != nil {
 		return err
 	}
-	u, err := parseSourceURL(r.URL)
-	if err != nil {
-		return fmt.Errorf("could not parse datasource URL %q: %w", r.URL, err)
-	}
 	*d = DSConfig{
-		URL:    u,
-		Header: r.Header,
+		Header:   r.Header,
+		Type:     r.Type,
+		Timeout:  r.Timeout,
+		Retries:  r.Retries,
+		Auth:     r.Auth,
+		CacheTTL: r.CacheTTL,
+		// a default implies the datasource is optional
+		Optional: r.Optional || r.Default != nil,
+		Default:  r.Default,
+		When:     r.When,
+	}
+	// a missing URL is caught in Validate
+	if r.URL != "" {
+		d.URL, err = parseSourceURL(r.URL)
+		if err != nil {
+			return fmt.Errorf("could not parse datasource URL %q: %w", r.URL, err)
+		}
 	}
 	return nil
 }
 
 // MarshalYAML - satisfy the yaml.Marshaler interface - URLs aren't
-// well supported, and anyway we need to do some extra parsing
+// well supported, and anyway we need to do some extra parsing. Credentials
+// are redacted, since this is only used for display.
 func (d DSConfig) MarshalYAML() (interface{}, error) {
-	type raw struct {
-		URL    string
-		Header http.Header
+	r := dsConfigRaw{
+		Header:   d.Header,
+		Type:     d.Type,
+		Timeout:  d.Timeout,
+		Retries:  d.Retries,
+		CacheTTL: d.CacheTTL,
+		Optional: d.Optional,
+		Default:  d.Default,
+		When:     d.When,
 	}
-	r := raw{
-		URL:    d.URL.String(),
-		Header: d.Header,
+	if d.URL != nil {
+		r.URL = d.URL.String()
+	}
+	if d.Auth != nil {
+		a := *d.Auth
+		if a.Password != "" {
+			a.Password = redacted
+		}
+		if a.Token != "" {
+			a.Token = redacted
+		}
+		r.Auth = &a
 	}
 	return r, nil
 }
 
+const redacted = "******"
+
 func (d DSConfig) mergeFrom(o DSConfig) DSConfig {
 	if o.URL != nil {
 		d.URL = o.URL
@@ -134,6 +410,27 @@ func (d DSConfig) mergeFrom(o DSConfig) DSConfig {
 			d.Header[k] = v
 		}
 	}
+	if o.Type != "" {
+		d.Type = o.Type
+	}
+	if o.Timeout != 0 {
+		d.Timeout = o.Timeout
+	}
+	if o.Retries != 0 {
+		d.Retries = o.Retries
+	}
+	if o.Auth != nil {
+		d.Auth = o.Auth
+	}
+	if o.CacheTTL != 0 {
+		d.CacheTTL = o.CacheTTL
+	}
+	if o.Optional {
+		d.Optional = o.Optional
+	}
+	if o.Default != nil {
+		d.Default = o.Default
+	}
 	return d
 }
 
@@ -197,9 +494,60 @@ func (c *Config) MergeFrom(o *Config) *Config {
 	if !isZero(o.Templates) {
 		c.Templates = o.Templates
 	}
-	c.DataSources.mergeFrom(o.DataSources)
-	c.Context.mergeFrom(o.Context)
+	if !isZero(o.EnableExec) {
+		c.EnableExec = o.EnableExec
+	}
+	if !isZero(o.EnableSprig) {
+		c.EnableSprig = o.EnableSprig
+	}
+	if !isZero(o.PostExec) {
+		c.PostExec = o.PostExec
+	}
+	if o.PostExecDir != "" {
+		c.PostExecDir = o.PostExecDir
+	}
+	if len(o.PostExecEnv) > 0 {
+		if c.PostExecEnv == nil {
+			c.PostExecEnv = map[string]string{}
+		}
+		for k, v := range o.PostExecEnv {
+			c.PostExecEnv[k] = v
+		}
+	}
+	if o.PostExecShell {
+		c.PostExecShell = o.PostExecShell
+	}
+	if !isZero(o.SuppressEmpty) {
+		c.SuppressEmpty = o.SuppressEmpty
+	}
+	if o.PluginTimeout != 0 {
+		c.PluginTimeout = o.PluginTimeout
+	}
+	if o.MissingKey != "" {
+		c.MissingKey = o.MissingKey
+	}
+	if o.MissingKeyDefault != "" {
+		c.MissingKeyDefault = o.MissingKeyDefault
+	}
+	// later values override earlier ones, so these accumulate
+	c.ValuesFiles = append(c.ValuesFiles, o.ValuesFiles...)
+	c.Delims = append(c.Delims, o.Delims...)
+	c.SetValues = append(c.SetValues, o.SetValues...)
+	c.SetFiles = append(c.SetFiles, o.SetFiles...)
+	c.DataSources = c.DataSources.mergeFrom(o.DataSources)
+	c.Context = c.Context.mergeFrom(o.Context)
+	if len(o.Profiles) > 0 {
+		if c.Profiles == nil {
+			c.Profiles = map[string]*Config{}
+		}
+		for k, v := range o.Profiles {
+			c.Profiles[k] = v
+		}
+	}
 	if len(o.Plugins) > 0 {
+		if c.Plugins == nil {
+			c.Plugins = map[string]PluginConfig{}
+		}
 		for k, v := range o.Plugins {
 			c.Plugins[k] = v
 		}
@@ -208,6 +556,43 @@ func (c *Config) MergeFrom(o *Config) *Config {
 	return c
 }
 
+// ApplyProfile - override this Config with the named profile from Profiles,
+// with MergeFrom. Profiles whose 'when' conditions are true are applied
+// first, in name order. An empty name applies only those, and leaves the
+// Config as-is if there are none.
+func (c *Config) ApplyProfile(name string) (*Config, error) {
+	auto, err := c.conditionalProfiles(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range auto {
+		c = c.MergeFrom(c.Profiles[k])
+	}
+	if name == "" {
+		if len(auto) > 0 {
+			c.Profiles = nil
+		}
+		return c, nil
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(c.Profiles))
+		for k := range c.Profiles {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown profile %q (available profiles: %s)", name, strings.Join(names, ", "))
+	}
+	if p != nil {
+		if len(p.Profiles) > 0 {
+			return nil, fmt.Errorf("profile %q must not define nested profiles", name)
+		}
+		c = c.MergeFrom(p)
+	}
+	c.Profiles = nil
+	return c, nil
+}
+
 // ParseDataSourceFlags - sets the DataSources and Context fields from the
 // key=value format flags as provided at the command-line
 func (c *Config) ParseDataSourceFlags(datasources, contexts, headers []string) error {
@@ -264,9 +649,9 @@ func (c *Config) ParsePluginFlags(plugins []string) error {
 			return fmt.Errorf("plugin requires both name and path")
 		}
 		if c.Plugins == nil {
-			c.Plugins = map[string]string{}
+			c.Plugins = map[string]PluginConfig{}
 		}
-		c.Plugins[parts[0]] = parts[1]
+		c.Plugins[parts[0]] = PluginConfig{Cmd: parts[1]}
 	}
 	return nil
 }
@@ -368,15 +753,154 @@ func (c Config) Validate() (err error) {
 		}
 	}
 
+	if err == nil && len(c.PostExec) == 0 &&
+		(c.PostExecDir != "" || len(c.PostExecEnv) > 0 || c.PostExecShell) {
+		err = fmt.Errorf("postExecDir, postExecEnv, and postExecShell may only be used with a postExec command")
+	}
+
 	if err == nil {
 		if c.ExecPipe && (len(c.OutputFiles) > 0 && c.OutputFiles[0] != "-") {
 			err = fmt.Errorf("must not set 'outputFiles' when using 'execPipe'")
 		}
 	}
 
+	if err == nil {
+		err = validateDataSources("datasources", c.DataSources)
+	}
+	if err == nil {
+		err = validateDataSources("context", c.Context)
+	}
+
+	if err == nil {
+		err = validatePlugins(c.Plugins)
+	}
+
+	if err == nil && c.OutMode != "" {
+		if _, perr := strconv.ParseUint(c.OutMode, 8, 32); perr != nil {
+			err = fmt.Errorf("invalid 'chmod' value %q: must be an octal file mode", c.OutMode)
+		}
+	}
+
+	if err == nil && c.PluginTimeout < 0 {
+		err = fmt.Errorf("'pluginTimeout' must not be negative")
+	}
+
+	if err == nil {
+		switch c.MissingKey {
+		case "", "error", "zero", "invalid", "default":
+		default:
+			err = fmt.Errorf("invalid 'missingKey' value %q: must be one of 'error', 'zero', 'invalid', or 'default'", c.MissingKey)
+		}
+	}
+	if err == nil && c.MissingKeyDefault != "" && c.MissingKey != "" && c.MissingKey != "default" {
+		err = fmt.Errorf("'missingKeyDefault' can only be used when 'missingKey' is 'default'")
+	}
+
+	if err == nil {
+		err = validateDelims(c.Delims)
+	}
+
+	if err == nil && c.When != "" {
+		err = fmt.Errorf("'when' may only be set on profiles")
+	}
+
+	if err == nil {
+		err = validateSetArgs("set", c.SetValues)
+	}
+	if err == nil {
+		err = validateSetArgs("setFiles", c.SetFiles)
+	}
+
 	return err
 }
 
+func validateDelims(delims []DelimConfig) error {
+	for _, d := range delims {
+		if d.Pattern == "" {
+			return fmt.Errorf("'delims' entries must have a 'pattern'")
+		}
+		if err := checkPattern(d.Pattern); err != nil {
+			return fmt.Errorf("invalid 'delims' pattern %q: %w", d.Pattern, err)
+		}
+		if d.LDelim == "" && d.RDelim == "" {
+			return fmt.Errorf("'delims' pattern %q must set 'leftDelim' or 'rightDelim'", d.Pattern)
+		}
+	}
+	return nil
+}
+
+// checkPattern - make sure the glob pattern is well-formed. glob.Match only
+// reports bad patterns when it gets to the bad part.
+func checkPattern(pattern string) error {
+	patterns, err := glob.ExpandBraces(pattern)
+	if err != nil {
+		return err
+	}
+	for _, p := range patterns {
+		for _, seg := range strings.Split(p, "/") {
+			if _, err := path.Match(seg, ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validatePlugins(plugins map[string]PluginConfig) error {
+	names := make([]string, 0, len(plugins))
+	for k := range plugins {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		p := plugins[k]
+		if p.Cmd == "" {
+			return fmt.Errorf("plugin '%s' must have a path", k)
+		}
+		if p.Timeout < 0 {
+			return fmt.Errorf("plugin '%s': 'timeout' must not be negative", k)
+		}
+		switch p.Stderr {
+		case "", "inherit", "discard", "capture":
+		default:
+			return fmt.Errorf("plugin '%s': invalid 'stderr' value %q: must be one of 'inherit', 'discard', or 'capture'", k, p.Stderr)
+		}
+	}
+	return nil
+}
+
+func validateSetArgs(name string, args []string) error {
+	for _, a := range args {
+		if i := strings.Index(a, "="); i < 1 {
+			return fmt.Errorf("invalid '%s' value %q: must be in key=value form", name, a)
+		}
+	}
+	return nil
+}
+
+func validateDataSources(name string, ds DSources) error {
+	aliases := make([]string, 0, len(ds))
+	for k := range ds {
+		aliases = append(aliases, k)
+	}
+	sort.Strings(aliases)
+	for _, k := range aliases {
+		if k == "" {
+			return fmt.Errorf("'%s' must not contain an empty alias", name)
+		}
+		if ds[k].URL == nil {
+			return fmt.Errorf("%s '%s' must have a 'url'", name, k)
+		}
+		if ds[k].Timeout < 0 || ds[k].Retries < 0 || ds[k].CacheTTL < 0 {
+			return fmt.Errorf("%s '%s': 'timeout', 'retries', and 'cacheTTL' must not be negative", name, k)
+		}
+		if a := ds[k].Auth; a != nil && a.Token != "" && (a.Username != "" || a.Password != "") {
+			return fmt.Errorf("%s '%s': only one of 'auth.token' or 'auth.username'/'auth.password' may be set", name, k)
+		}
+	}
+	return nil
+}
+
 func notTogether(names []string, values ...interface{}) error {
 	found := ""
 	for i, value := range values {
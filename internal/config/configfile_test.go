@@ -67,6 +67,168 @@ pluginTimeout: 2s
 	assert.EqualValues(t, expected, cf)
 }
 
+func TestParsePluginConfig(t *testing.T) {
+	t.Parallel()
+	in := `plugins:
+  simple: /bin/simple
+  full:
+    cmd: /bin/full
+    args: [--quiet, -x]
+    timeout: 10s
+    env: [HOME, AWS_REGION]
+    stderr: capture
+  noenv:
+    cmd: noenv.sh
+    env: []
+`
+	cfg, err := Parse(strings.NewReader(in))
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]PluginConfig{
+		"simple": {Cmd: "/bin/simple"},
+		"full": {
+			Cmd:     "/bin/full",
+			Args:    []string{"--quiet", "-x"},
+			Timeout: 10 * time.Second,
+			Env:     []string{"HOME", "AWS_REGION"},
+			Stderr:  "capture",
+		},
+		"noenv": {Cmd: "noenv.sh", Env: []string{}},
+	}, cfg.Plugins)
+
+	// the simple form is kept when marshaling
+	assert.Equal(t, `---
+plugins:
+  full:
+    cmd: /bin/full
+    args: [--quiet, -x]
+    timeout: 10s
+    env: [HOME, AWS_REGION]
+    stderr: capture
+  noenv:
+    cmd: noenv.sh
+    env: []
+  simple: /bin/simple
+`, cfg.String())
+
+	cfg, err = ParseFormat(strings.NewReader(`{"plugins": {"foo": "foo.sh", "bar": {"cmd": "bar.sh", "timeout": "1s"}}}`), "json")
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]PluginConfig{
+		"foo": {Cmd: "foo.sh"},
+		"bar": {Cmd: "bar.sh", Timeout: time.Second},
+	}, cfg.Plugins)
+}
+
+func TestParseDSConfigOptions(t *testing.T) {
+	t.Parallel()
+	in := `datasources:
+  api:
+    url: https://example.com/api
+    type: application/json
+    timeout: 10s
+    retries: 3
+    cacheTTL: 1m
+    auth:
+      username: me
+      password: secret
+  maybe:
+    url: https://example.com/maybe.json
+    default:
+      foo: bar
+  optional:
+    url: https://example.com/optional.json
+    optional: true
+`
+	expected := &Config{
+		DataSources: DSources{
+			"api": {
+				URL:      mustURL("https://example.com/api"),
+				Type:     "application/json",
+				Timeout:  10 * time.Second,
+				Retries:  3,
+				CacheTTL: time.Minute,
+				Auth:     &DSAuth{Username: "me", Password: "secret"},
+			},
+			"maybe": {
+				URL:      mustURL("https://example.com/maybe.json"),
+				Optional: true,
+				Default:  map[string]interface{}{"foo": "bar"},
+			},
+			"optional": {
+				URL:      mustURL("https://example.com/optional.json"),
+				Optional: true,
+			},
+		},
+	}
+	cf, err := Parse(strings.NewReader(in))
+	assert.NoError(t, err)
+	assert.EqualValues(t, expected, cf)
+
+	// credentials are redacted for display
+	assert.Contains(t, cf.String(), "password: '******'")
+	assert.NotContains(t, cf.String(), "secret")
+
+	cf = cf.MergeFrom(&Config{
+		DataSources: DSources{
+			"api": {Retries: 5, Auth: &DSAuth{Token: "abcd"}},
+		},
+	})
+	assert.Equal(t, 5, cf.DataSources["api"].Retries)
+	assert.Equal(t, 10*time.Second, cf.DataSources["api"].Timeout)
+	assert.Equal(t, &DSAuth{Token: "abcd"}, cf.DataSources["api"].Auth)
+
+	assert.Error(t, validateConfig(`datasources:
+  api:
+    url: https://example.com/api
+    retries: -1
+`))
+	assert.Error(t, validateConfig(`datasources:
+  api:
+    url: https://example.com/api
+    auth:
+      username: me
+      token: abcd
+`))
+}
+
+func TestParseConfigFileEnvExpansion(t *testing.T) {
+	os.Setenv("CFG_API_HOST", "api.example.com")
+	os.Setenv("CFG_TOKEN", "abcd1234")
+	defer os.Unsetenv("CFG_API_HOST")
+	defer os.Unsetenv("CFG_TOKEN")
+
+	in := `in: '{{ $x := "${CFG_UNSET:-hi}" }}{{ $x }}'
+outputFiles: ["${CFG_OUT:-out.txt}"]
+datasources:
+  api:
+    url: https://${CFG_API_HOST}/v1/data.json
+    header:
+      Authorization: ["Bearer ${CFG_TOKEN}"]
+  ${CFG_API_HOST}:
+    url: $${literal}
+`
+	expected := &Config{
+		Input:       `{{ $x := "hi" }}{{ $x }}`,
+		OutputFiles: []string{"out.txt"},
+		DataSources: map[string]DSConfig{
+			"api": {
+				URL: mustURL("https://api.example.com/v1/data.json"),
+				Header: map[string][]string{
+					"Authorization": {"Bearer abcd1234"},
+				},
+			},
+			"${CFG_API_HOST}": {
+				URL: mustURL("${literal}"),
+			},
+		},
+	}
+	cf, err := Parse(strings.NewReader(in))
+	assert.NoError(t, err)
+	assert.EqualValues(t, expected, cf)
+
+	_, err = Parse(strings.NewReader("in: foo\noutputDir: ${CFG_UNSET:?must be set}\n"))
+	assert.EqualError(t, err, "line 2: CFG_UNSET: must be set")
+}
+
 func mustURL(s string) *url.URL {
 	u, err := url.Parse(s)
 	if err != nil {
@@ -160,6 +322,80 @@ postExec: [echo]
 `))
 }
 
+func TestValidateCrossField(t *testing.T) {
+	t.Parallel()
+	assert.EqualError(t, validateConfig(`datasources:
+  data:
+    header:
+      Accept: [application/json]
+`), "datasources 'data' must have a 'url'")
+
+	assert.EqualError(t, validateConfig(`context:
+  data: {}
+`), "context 'data' must have a 'url'")
+
+	assert.EqualError(t, validateConfig(`plugins:
+  foo: ""
+`), "plugin 'foo' must have a path")
+
+	assert.EqualError(t, validateConfig(`plugins:
+  foo:
+    args: [bar]
+`), "plugin 'foo' must have a path")
+
+	assert.EqualError(t, validateConfig(`plugins:
+  foo:
+    cmd: foo.sh
+    timeout: -1s
+`), "plugin 'foo': 'timeout' must not be negative")
+
+	assert.EqualError(t, validateConfig(`plugins:
+  foo:
+    cmd: foo.sh
+    stderr: stdout
+`), `plugin 'foo': invalid 'stderr' value "stdout": must be one of 'inherit', 'discard', or 'capture'`)
+
+	assert.EqualError(t, validateConfig(`chmod: 999
+`), `invalid 'chmod' value "999": must be an octal file mode`)
+
+	assert.EqualError(t, validateConfig(`pluginTimeout: -1s
+`), "'pluginTimeout' must not be negative")
+
+	assert.EqualError(t, validateConfig(`postExecDir: /tmp
+postExecShell: true
+`), "postExecDir, postExecEnv, and postExecShell may only be used with a postExec command")
+
+	assert.EqualError(t, validateConfig(`missingKey: ignore
+`), `invalid 'missingKey' value "ignore": must be one of 'error', 'zero', 'invalid', or 'default'`)
+
+	assert.EqualError(t, validateConfig(`missingKey: zero
+missingKeyDefault: N/A
+`), "'missingKeyDefault' can only be used when 'missingKey' is 'default'")
+
+	assert.EqualError(t, validateConfig(`set: [foo]
+`), `invalid 'set' value "foo": must be in key=value form`)
+
+	assert.EqualError(t, validateConfig(`setFiles: ["=foo.txt"]
+`), `invalid 'setFiles' value "=foo.txt": must be in key=value form`)
+
+	assert.NoError(t, validateConfig(`chmod: 755
+pluginTimeout: 1s
+set: ["a.b=c,d=e"]
+missingKeyDefault: N/A
+setFiles: ["cert=cert.pem"]
+postExec: [make build && ./deploy.sh]
+postExecShell: true
+postExecDir: /tmp
+postExecEnv:
+  FOO: bar
+plugins:
+  foo: /bin/foo
+datasources:
+  data:
+    url: data.json
+`))
+}
+
 func validateConfig(c string) error {
 	in := strings.NewReader(c)
 	cfg, err := Parse(in)
@@ -293,28 +529,104 @@ func TestMergeFrom(t *testing.T) {
 	cfg = &Config{
 		Input:       "hello world",
 		OutputFiles: []string{"-"},
-		Plugins: map[string]string{
-			"sleep": "echo",
+		Plugins: map[string]PluginConfig{
+			"sleep": {Cmd: "echo"},
 		},
 		PluginTimeout: 500 * time.Microsecond,
 	}
 	other = &Config{
 		InputFiles:  []string{"-"},
 		OutputFiles: []string{"-"},
-		Plugins: map[string]string{
-			"sleep": "sleep.sh",
+		Plugins: map[string]PluginConfig{
+			"sleep": {Cmd: "sleep.sh"},
 		},
 	}
 	expected = &Config{
 		Input:       "hello world",
 		OutputFiles: []string{"-"},
-		Plugins: map[string]string{
-			"sleep": "sleep.sh",
+		Plugins: map[string]PluginConfig{
+			"sleep": {Cmd: "sleep.sh"},
 		},
 		PluginTimeout: 500 * time.Microsecond,
 	}
 
 	assert.EqualValues(t, expected, cfg.MergeFrom(other))
+
+	cfg = &Config{Input: "hello world"}
+	other = &Config{EnableExec: true}
+	expected = &Config{Input: "hello world", EnableExec: true}
+
+	assert.EqualValues(t, expected, cfg.MergeFrom(other))
+
+	cfg = &Config{Input: "hello world"}
+	other = &Config{EnableSprig: true}
+	expected = &Config{Input: "hello world", EnableSprig: true}
+
+	assert.EqualValues(t, expected, cfg.MergeFrom(other))
+
+	cfg = &Config{SetValues: []string{"a=b"}, SetFiles: []string{"c=c.txt"}, ValuesFiles: []string{"a.yaml"}}
+	other = &Config{SetValues: []string{"a=c"}, ValuesFiles: []string{"b.yaml"}}
+	expected = &Config{
+		SetValues:   []string{"a=b", "a=c"},
+		SetFiles:    []string{"c=c.txt"},
+		ValuesFiles: []string{"a.yaml", "b.yaml"},
+	}
+
+	assert.EqualValues(t, expected, cfg.MergeFrom(other))
+}
+
+func TestApplyProfile(t *testing.T) {
+	t.Parallel()
+	cfg, err := Parse(strings.NewReader(`inputDir: in/
+outputDir: out/
+datasources:
+  data:
+    url: https://dev.example.com/data.json
+  other:
+    url: file:///other.json
+profiles:
+  prod:
+    outputDir: out/prod/
+    datasources:
+      data:
+        url: https://prod.example.com/data.json
+  staging:
+`))
+	assert.NoError(t, err)
+
+	out, err := cfg.ApplyProfile("")
+	assert.NoError(t, err)
+	assert.Same(t, cfg, out)
+
+	_, err = cfg.ApplyProfile("qa")
+	assert.EqualError(t, err, `unknown profile "qa" (available profiles: prod, staging)`)
+
+	out, err = cfg.ApplyProfile("prod")
+	assert.NoError(t, err)
+	assert.EqualValues(t, &Config{
+		InputDir:  "in/",
+		OutputDir: "out/prod/",
+		DataSources: DSources{
+			"data":  {URL: mustURL("https://prod.example.com/data.json")},
+			"other": {URL: mustURL("file:///other.json")},
+		},
+	}, out)
+
+	cfg = &Config{
+		Input:    "hi",
+		Profiles: map[string]*Config{"empty": nil},
+	}
+	out, err = cfg.ApplyProfile("empty")
+	assert.NoError(t, err)
+	assert.EqualValues(t, &Config{Input: "hi"}, out)
+
+	cfg = &Config{
+		Profiles: map[string]*Config{
+			"a": {Profiles: map[string]*Config{"b": {}}},
+		},
+	}
+	_, err = cfg.ApplyProfile("a")
+	assert.Error(t, err)
 }
 
 func TestParseDataSourceFlags(t *testing.T) {
@@ -389,7 +701,7 @@ func TestParsePluginFlags(t *testing.T) {
 	cfg = &Config{}
 	err = cfg.ParsePluginFlags([]string{"foo=bar"})
 	assert.NoError(t, err)
-	assert.EqualValues(t, &Config{Plugins: map[string]string{"foo": "bar"}}, cfg)
+	assert.EqualValues(t, &Config{Plugins: map[string]PluginConfig{"foo": {Cmd: "bar"}}}, cfg)
 }
 
 func TestConfigString(t *testing.T) {
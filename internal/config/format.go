@@ -0,0 +1,74 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hairyhenderson/toml"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	tomlTableRe = regexp.MustCompile(`^\[\[?\s*[\w"'.\- ]+\s*\]\]?\s*(#.*)?$`)
+	tomlKeyRe   = regexp.MustCompile(`^[\w"'.\-]+\s*=`)
+)
+
+// FormatFromName - the config file format implied by the file name's
+// extension ("yaml", "json", or "toml"), or "" if it can't be determined.
+func FormatFromName(name string) string {
+	// strip any query string, in case this is a URL
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".json":
+		return "json"
+	case ".toml":
+		return "toml"
+	default:
+		return ""
+	}
+}
+
+// sniffFormat - guess the format from the content: the first line that isn't
+// blank or a comment will look like a TOML table header or key/value pair in
+// TOML files. JSON is handled by the YAML decoder.
+func sniffFormat(b []byte) string {
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if tomlTableRe.MatchString(line) || tomlKeyRe.MatchString(line) {
+			return "toml"
+		}
+		return "yaml"
+	}
+	return "yaml"
+}
+
+// tomlNode - decode TOML into a YAML node, so it can be decoded into a Config
+// in the usual way
+func tomlNode(b []byte) (*yaml.Node, error) {
+	m := map[string]interface{}{}
+	err := toml.Unmarshal(b, &m)
+	if err != nil {
+		return nil, err
+	}
+	node := &yaml.Node{}
+	if len(m) == 0 {
+		return node, nil
+	}
+	y, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	err = yaml.Unmarshal(y, node)
+	return node, err
+}
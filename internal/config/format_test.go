@@ -0,0 +1,115 @@
+package config
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+	expected := &Config{
+		Input:       "hello world",
+		OutputFiles: []string{"out.txt"},
+		DataSources: DSources{
+			"data": {
+				URL: mustURL("https://example.com/data.json"),
+				Header: http.Header{
+					"Authorization": {"Bearer abcd1234"},
+				},
+			},
+		},
+		PluginTimeout: 2 * time.Second,
+	}
+
+	yamlIn := `in: hello world
+outputFiles: [out.txt]
+datasources:
+  data:
+    url: https://example.com/data.json
+    header:
+      Authorization: ["Bearer abcd1234"]
+pluginTimeout: 2s
+`
+	jsonIn := `{
+  "in": "hello world",
+  "outputFiles": ["out.txt"],
+  "datasources": {
+    "data": {
+      "url": "https://example.com/data.json",
+      "header": {"Authorization": ["Bearer abcd1234"]}
+    }
+  },
+  "pluginTimeout": "2s"
+}`
+	tomlIn := `# a comment
+in = "hello world"
+outputFiles = ["out.txt"]
+pluginTimeout = "2s"
+
+[datasources.data]
+url = "https://example.com/data.json"
+header = { Authorization = ["Bearer abcd1234"] }
+`
+
+	for _, d := range []struct{ format, in string }{
+		{"yaml", yamlIn},
+		{"json", jsonIn},
+		{"toml", tomlIn},
+	} {
+		cfg, err := ParseFormat(strings.NewReader(d.in), d.format)
+		assert.NoError(t, err, d.format)
+		assert.EqualValues(t, expected, cfg, d.format)
+
+		// sniffed
+		cfg, err = Parse(strings.NewReader(d.in))
+		assert.NoError(t, err, d.format)
+		assert.EqualValues(t, expected, cfg, d.format)
+	}
+
+	cfg, err := ParseFormat(strings.NewReader(""), "toml")
+	assert.NoError(t, err)
+	assert.EqualValues(t, &Config{}, cfg)
+
+	_, err = ParseFormat(strings.NewReader(yamlIn), "toml")
+	assert.Error(t, err)
+
+	_, err = ParseFormat(strings.NewReader(yamlIn), "xml")
+	assert.EqualError(t, err, `unsupported config file format "xml"`)
+}
+
+func TestFormatFromName(t *testing.T) {
+	t.Parallel()
+	data := map[string]string{
+		".gomplate.yaml":                     "yaml",
+		"config.YML":                         "yaml",
+		"/etc/gomplate.json":                 "json",
+		"gomplate.toml":                      "toml",
+		"https://example.com/c.toml?ref=abc": "toml",
+		"config":                             "",
+	}
+	for in, expected := range data {
+		assert.Equal(t, expected, FormatFromName(in), in)
+	}
+}
+
+func TestSniffFormat(t *testing.T) {
+	t.Parallel()
+	data := map[string]string{
+		"":                               "yaml",
+		"in: foo":                        "yaml",
+		"{\"in\": \"foo\"}":              "yaml",
+		"# comment\n\n[datasources.foo]": "toml",
+		"[[foo]]":                        "toml",
+		"in = 'foo'":                     "toml",
+		"\"in\" = 'foo'":                 "toml",
+		"- [a, b]":                       "yaml",
+		"[a, b]":                         "yaml",
+	}
+	for in, expected := range data {
+		assert.Equal(t, expected, sniffFormat([]byte(in)), in)
+	}
+}
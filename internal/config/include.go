@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// OpenFunc - opens the named config file for reading. The name may be a local
+// path or a URL.
+type OpenFunc func(name string) (io.ReadCloser, error)
+
+// ResolveIncludes - merge in the config files listed in Include, recursively.
+// The name is this config's own path or URL, and relative includes are
+// resolved against it.
+//
+// Included files are merged in order (with MergeFrom), and this config is
+// merged last, so it takes precedence over anything it includes. When strict
+// is set, included files are parsed with ParseStrict.
+func (c *Config) ResolveIncludes(name string, open OpenFunc, strict bool) (*Config, error) {
+	return c.resolveIncludes([]string{name}, open, strict)
+}
+
+func (c *Config) resolveIncludes(stack []string, open OpenFunc, strict bool) (*Config, error) {
+	if len(c.Include) == 0 {
+		return c, nil
+	}
+
+	parent := stack[len(stack)-1]
+	out := &Config{}
+	for _, inc := range c.Include {
+		name := resolveIncludeName(parent, inc)
+		for _, s := range stack {
+			if s == name {
+				return nil, fmt.Errorf("config include cycle: %s -> %s", strings.Join(stack, " -> "), name)
+			}
+		}
+
+		ic, err := parseIncluded(name, open, strict)
+		if err != nil {
+			return nil, err
+		}
+		ic, err = ic.resolveIncludes(append(stack[:len(stack):len(stack)], name), open, strict)
+		if err != nil {
+			return nil, err
+		}
+		out = out.MergeFrom(ic)
+	}
+
+	out = out.MergeFrom(c)
+	out.Include = nil
+	return out, nil
+}
+
+func parseIncluded(name string, open OpenFunc, strict bool) (*Config, error) {
+	f, err := open(name)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open included config file %s: %w", name, err)
+	}
+	// nolint: errcheck
+	defer f.Close()
+	cfg, err := parse(f, FormatFromName(name), strict)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse included config file %s: %w", name, err)
+	}
+	return cfg, nil
+}
+
+// resolveIncludeName - resolve the include relative to the including file.
+// URLs and absolute paths are used as-is.
+func resolveIncludeName(parent, name string) string {
+	if isURL(name) {
+		return name
+	}
+	if isURL(parent) {
+		base, err := url.Parse(parent)
+		if err != nil {
+			return name
+		}
+		ref, err := url.Parse(filepath.ToSlash(name))
+		if err != nil {
+			return name
+		}
+		return base.ResolveReference(ref).String()
+	}
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(filepath.Dir(parent), name)
+}
+
+// isURL - whether the name looks like a URL rather than a path. Single-letter
+// schemes are assumed to be Windows drive letters.
+func isURL(name string) bool {
+	u, err := url.Parse(name)
+	return err == nil && len(u.Scheme) > 1
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func memOpener(files map[string]string) OpenFunc {
+	return func(name string) (io.ReadCloser, error) {
+		s, ok := files[filepath.ToSlash(name)]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return ioutil.NopCloser(strings.NewReader(s)), nil
+	}
+}
+
+func TestResolveIncludes(t *testing.T) {
+	t.Parallel()
+	open := memOpener(map[string]string{
+		"conf/base.yaml": `include: [https://example.com/org.yaml]
+pluginTimeout: 2s
+datasources:
+  data:
+    url: https://example.com/base.json
+`,
+		"https://example.com/org.yaml": `include: [common/ds.yaml]
+leftDelim: '(('
+rightDelim: '))'
+`,
+		"https://example.com/common/ds.yaml": `datasources:
+  org:
+    url: https://example.com/org.json
+`,
+		"conf/typo.yaml":   "outputdir: foo\n",
+		"conf/cycle.yaml":  "include: [cycle2.yaml]\n",
+		"conf/cycle2.yaml": "include: [cycle.yaml]\n",
+	})
+
+	cfg, err := Parse(strings.NewReader(`include: [base.yaml]
+in: hello
+leftDelim: '<<'
+datasources:
+  data:
+    url: https://example.com/override.json
+`))
+	assert.NoError(t, err)
+
+	cfg, err = cfg.ResolveIncludes("conf/main.yaml", open, false)
+	assert.NoError(t, err)
+	assert.EqualValues(t, &Config{
+		Input:         "hello",
+		LDelim:        "<<",
+		RDelim:        "))",
+		PluginTimeout: 2 * time.Second,
+		DataSources: DSources{
+			"data": {URL: mustURL("https://example.com/override.json")},
+			"org":  {URL: mustURL("https://example.com/org.json")},
+		},
+	}, cfg)
+
+	cfg = &Config{Input: "hi"}
+	out, err := cfg.ResolveIncludes("conf/main.yaml", open, false)
+	assert.NoError(t, err)
+	assert.Same(t, cfg, out)
+
+	cfg = &Config{Include: []string{"cycle.yaml"}}
+	_, err = cfg.ResolveIncludes("conf/main.yaml", open, false)
+	assert.EqualError(t, err, "config include cycle: "+strings.Join([]string{
+		"conf/main.yaml",
+		filepath.Join("conf", "cycle.yaml"),
+		filepath.Join("conf", "cycle2.yaml"),
+		filepath.Join("conf", "cycle.yaml"),
+	}, " -> "))
+
+	cfg = &Config{Include: []string{"typo.yaml"}}
+	_, err = cfg.ResolveIncludes("conf/main.yaml", open, false)
+	assert.NoError(t, err)
+	_, err = cfg.ResolveIncludes("conf/main.yaml", open, true)
+	assert.Error(t, err)
+
+	cfg = &Config{Include: []string{"missing.yaml"}}
+	_, err = cfg.ResolveIncludes("main.yaml", open, false)
+	assert.Error(t, err)
+}
+
+func TestResolveIncludeName(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		parent, name, expected string
+	}{
+		{".gomplate.yaml", "base.yaml", "base.yaml"},
+		{"conf/.gomplate.yaml", "base.yaml", filepath.Join("conf", "base.yaml")},
+		{"conf/.gomplate.yaml", "../base.yaml", "base.yaml"},
+		{"conf/.gomplate.yaml", "https://example.com/base.yaml", "https://example.com/base.yaml"},
+		{"https://example.com/a/b.yaml", "c.yaml", "https://example.com/a/c.yaml"},
+		{"https://example.com/a/b.yaml", "/c.yaml", "https://example.com/c.yaml"},
+	}
+	for _, d := range data {
+		assert.Equal(t, d.expected, resolveIncludeName(d.parent, d.name), d)
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Provenance - records where each setting came from (a config file, flags,
+// environment variables, or defaults), keyed by the setting's YAML name
+type Provenance struct {
+	sources map[string]string
+	last    map[string]string
+}
+
+// Track - record the source of any settings which have changed since the
+// last call (or which have been set, on the first call)
+func (p *Provenance) Track(c *Config, source string) {
+	if p.sources == nil {
+		p.sources = map[string]string{}
+		p.last = settingValues(&Config{})
+	}
+	current := settingValues(c)
+	for k, v := range current {
+		if p.last[k] != v {
+			p.sources[k] = source
+		}
+	}
+	p.last = current
+}
+
+// Source - where the named setting came from, or "" if it's unset
+func (p *Provenance) Source(name string) string {
+	return p.sources[name]
+}
+
+// settingValues - the YAML-encoded value of each setting, so they can be
+// compared without worrying about shared maps and slices
+func settingValues(c *Config) map[string]string {
+	values := map[string]string{}
+	v := reflect.ValueOf(*c)
+	for name, f := range yamlFields(v.Type()) {
+		b, err := yaml.Marshal(v.FieldByIndex(f.Index).Interface())
+		if err != nil {
+			b = []byte(err.Error())
+		}
+		values[name] = string(b)
+	}
+	return values
+}
+
+// StringWithProvenance - like String, but each setting is annotated with a
+// comment saying where it came from
+func (c *Config) StringWithProvenance(p *Provenance) string {
+	n := &yaml.Node{}
+	err := yaml.Unmarshal([]byte(c.String()), n)
+	if err != nil {
+		return err.Error()
+	}
+	if len(n.Content) > 0 && n.Content[0].Kind == yaml.MappingNode {
+		m := n.Content[0]
+		for i := 0; i+1 < len(m.Content); i += 2 {
+			src := p.Source(m.Content[i].Value)
+			if src == "" {
+				continue
+			}
+			// comments on block values go after the key
+			if v := m.Content[i+1]; v.Kind == yaml.ScalarNode || v.Style&yaml.FlowStyle != 0 {
+				v.LineComment = "from " + src
+			} else {
+				m.Content[i].LineComment = "from " + src
+			}
+		}
+	}
+
+	out := &strings.Builder{}
+	out.WriteString("---\n")
+	enc := yaml.NewEncoder(out)
+	enc.SetIndent(2)
+	if err := enc.Encode(n); err != nil {
+		return err.Error()
+	}
+	return out.String()
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvenance(t *testing.T) {
+	t.Parallel()
+	p := &Provenance{}
+
+	cfg := &Config{
+		InputDir:  "in/",
+		OutputDir: "out/",
+		DataSources: DSources{
+			"data": {URL: mustURL("https://example.com/data.json")},
+		},
+	}
+	p.Track(cfg, "config file .gomplate.yaml")
+
+	cfg = cfg.MergeFrom(&Config{
+		OutputDir: "build/",
+		DataSources: DSources{
+			"more": {URL: mustURL("https://example.com/more.json")},
+		},
+	})
+	p.Track(cfg, "flags")
+
+	cfg.PluginTimeout = 2 * time.Second
+	p.Track(cfg, "environment variable")
+
+	cfg.ApplyDefaults()
+	p.Track(cfg, "default")
+
+	assert.Equal(t, "config file .gomplate.yaml", p.Source("inputDir"))
+	assert.Equal(t, "flags", p.Source("outputDir"))
+	assert.Equal(t, "flags", p.Source("datasources"))
+	assert.Equal(t, "environment variable", p.Source("pluginTimeout"))
+	assert.Equal(t, "default", p.Source("leftDelim"))
+	assert.Equal(t, "", p.Source("outputMap"))
+
+	assert.Equal(t, `---
+inputDir: in/ # from config file .gomplate.yaml
+outputDir: build/ # from flags
+leftDelim: '{{' # from default
+rightDelim: '}}' # from default
+datasources: # from flags
+  data:
+    url: https://example.com/data.json
+  more:
+    url: https://example.com/more.json
+pluginTimeout: 2s # from environment variable
+`, cfg.StringWithProvenance(p))
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnknownKeysError - returned by ParseStrict when the config contains keys
+// that don't correspond to any setting
+type UnknownKeysError struct {
+	Keys []UnknownKey
+}
+
+// UnknownKey - a key that doesn't correspond to any setting. Line is 0 when
+// it's unknown.
+type UnknownKey struct {
+	Path       string
+	Line       int
+	Suggestion string
+}
+
+func (k UnknownKey) String() string {
+	s := fmt.Sprintf("unknown key %q", k.Path)
+	if k.Line > 0 {
+		s = fmt.Sprintf("line %d: %s", k.Line, s)
+	}
+	if k.Suggestion != "" {
+		s += fmt.Sprintf(" (did you mean %q?)", k.Suggestion)
+	}
+	return s
+}
+
+func (e *UnknownKeysError) Error() string {
+	msgs := make([]string, len(e.Keys))
+	for i, k := range e.Keys {
+		msgs[i] = k.String()
+	}
+	return "invalid config: " + strings.Join(msgs, "; ")
+}
+
+var (
+	dsConfigKeys = []string{"url", "header", "type", "timeout", "retries", "auth", "cacheTTL", "optional", "default", "when"}
+	dsAuthKeys   = []string{"username", "password", "token"}
+	pluginKeys   = []string{"cmd", "args", "timeout", "env", "stderr", "when"}
+	delimKeys    = []string{"pattern", "leftDelim", "rightDelim"}
+)
+
+// checkKeys - make sure all keys in the mapping node are known for the given
+// struct type
+func checkKeys(n *yaml.Node, t reflect.Type, withLines bool) error {
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	unknown := []UnknownKey{}
+	checkConfigKeys(n, t, "", withLines, &unknown)
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.SliceStable(unknown, func(i, j int) bool {
+		return unknown[i].Line < unknown[j].Line
+	})
+	return &UnknownKeysError{Keys: unknown}
+}
+
+func checkConfigKeys(n *yaml.Node, t reflect.Type, prefix string, withLines bool, unknown *[]UnknownKey) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	known := yamlFields(t)
+	names := make([]string, 0, len(known))
+	for k := range known {
+		names = append(names, k)
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		path := prefix + k.Value
+		f, ok := known[k.Value]
+		if !ok {
+			*unknown = append(*unknown, unknownKey(k, path, names, withLines))
+			continue
+		}
+
+		switch f.Type {
+		case reflect.TypeOf(DSources{}):
+			checkMapValues(v, path, withLines, unknown, func(dv *yaml.Node, dpath string) {
+				checkKeyList(dv, dsConfigKeys, dpath, withLines, unknown)
+				if a := mapValue(dv, "auth"); a != nil {
+					checkKeyList(a, dsAuthKeys, dpath+"auth.", withLines, unknown)
+				}
+			})
+		case reflect.TypeOf(map[string]PluginConfig{}):
+			checkMapValues(v, path, withLines, unknown, func(pv *yaml.Node, ppath string) {
+				checkKeyList(pv, pluginKeys, ppath, withLines, unknown)
+			})
+		case reflect.TypeOf([]DelimConfig{}):
+			if v.Kind == yaml.SequenceNode {
+				for i, dv := range v.Content {
+					checkKeyList(dv, delimKeys, fmt.Sprintf("%s[%d].", path, i), withLines, unknown)
+				}
+			}
+		case reflect.TypeOf(map[string]*Config{}):
+			checkMapValues(v, path, withLines, unknown, func(pv *yaml.Node, ppath string) {
+				checkConfigKeys(pv, t, ppath, withLines, unknown)
+			})
+		}
+	}
+}
+
+// checkMapValues - call fn for each value in the mapping node
+func checkMapValues(n *yaml.Node, prefix string, withLines bool, unknown *[]UnknownKey, fn func(*yaml.Node, string)) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		fn(n.Content[i+1], prefix+"."+n.Content[i].Value+".")
+	}
+}
+
+// mapValue - the value for the given key in the mapping node, or nil
+func mapValue(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func checkKeyList(n *yaml.Node, names []string, prefix string, withLines bool, unknown *[]UnknownKey) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k := n.Content[i]
+		found := false
+		for _, name := range names {
+			if k.Value == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			*unknown = append(*unknown, unknownKey(k, prefix+k.Value, names, withLines))
+		}
+	}
+}
+
+func unknownKey(k *yaml.Node, path string, names []string, withLines bool) UnknownKey {
+	u := UnknownKey{Path: path}
+	if withLines {
+		u.Line = k.Line
+	}
+	for _, name := range names {
+		if strings.EqualFold(name, k.Value) {
+			u.Suggestion = name
+			break
+		}
+	}
+	return u
+}
+
+// yamlFields - the fields of the struct type, keyed by YAML name. Fields
+// which can't be set in YAML are omitted.
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("yaml")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f
+	}
+	return fields
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStrict(t *testing.T) {
+	t.Parallel()
+	in := `in: hello
+outputdir: out/
+datasources:
+  data:
+    url: https://example.com/data.json
+    headers:
+      Accept: [application/json]
+profiles:
+  prod:
+    inputDir: in/
+    bogus: true
+`
+	cfg, err := Parse(strings.NewReader(in))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", cfg.Input)
+
+	_, err = ParseStrict(strings.NewReader(in), "")
+	assert.EqualError(t, err, `invalid config: line 2: unknown key "outputdir" (did you mean "outputDir"?); `+
+		`line 6: unknown key "datasources.data.headers"; `+
+		`line 11: unknown key "profiles.prod.bogus"`)
+
+	uerr, ok := err.(*UnknownKeysError)
+	assert.True(t, ok)
+	assert.Len(t, uerr.Keys, 3)
+
+	_, err = ParseStrict(strings.NewReader(`datasources:
+  data:
+    url: https://example.com/data.json
+    auth:
+      user: me
+`), "")
+	assert.EqualError(t, err, `invalid config: line 5: unknown key "datasources.data.auth.user"`)
+
+	_, err = ParseStrict(strings.NewReader(`plugins:
+  foo: /bin/foo
+  bar:
+    cmd: /bin/bar
+    timeout: 1s
+    environment: [HOME]
+`), "")
+	assert.EqualError(t, err, `invalid config: line 6: unknown key "plugins.bar.environment"`)
+
+	_, err = ParseStrict(strings.NewReader("in = 'hello'\nInputDir = 'foo'\n"), "toml")
+	assert.EqualError(t, err, `invalid config: unknown key "InputDir" (did you mean "inputDir"?)`)
+
+	cfg, err = ParseStrict(strings.NewReader(`in: hello
+outputFiles: [out]
+datasources:
+  data:
+    url: https://example.com/data.json
+    header:
+      Accept: [application/json]
+    timeout: 5s
+    auth:
+      token: abcd
+profiles:
+  prod:
+    in: bye
+`), "yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", cfg.Input)
+
+	cfg, err = ParseStrict(strings.NewReader(""), "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, &Config{}, cfg)
+}
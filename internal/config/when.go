@@ -0,0 +1,248 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// for overriding in tests
+var (
+	goos   = runtime.GOOS
+	goarch = runtime.GOARCH
+)
+
+// evalCondition - evaluate a 'when' condition. Conditions compare the
+// variables os, arch, and env.NAME (an environment variable) with quoted
+// strings, using == and !=, and can be combined with &&, ||, !, and
+// parentheses. A variable on its own is true when it's non-empty.
+//
+// For example: os == "windows" || (arch != "amd64" && !env.CI)
+func evalCondition(expr string) (bool, error) {
+	toks, err := tokenizeCondition(expr)
+	if err != nil {
+		return false, fmt.Errorf("invalid condition %q: %w", expr, err)
+	}
+	p := &condParser{toks: toks}
+	v, err := p.or()
+	if err == nil && p.pos < len(p.toks) {
+		err = fmt.Errorf("unexpected %q", p.toks[p.pos])
+	}
+	if err != nil {
+		return false, fmt.Errorf("invalid condition %q: %w", expr, err)
+	}
+	return v, nil
+}
+
+func tokenizeCondition(expr string) ([]string, error) {
+	toks := []string{}
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')':
+			toks = append(toks, string(c))
+			i++
+		case strings.HasPrefix(expr[i:], "=="), strings.HasPrefix(expr[i:], "!="),
+			strings.HasPrefix(expr[i:], "&&"), strings.HasPrefix(expr[i:], "||"):
+			toks = append(toks, expr[i:i+2])
+			i += 2
+		case c == '!':
+			toks = append(toks, "!")
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			toks = append(toks, expr[i:i+end+2])
+			i += end + 2
+		case isIdentChar(rune(c)):
+			start := i
+			for i < len(expr) && isIdentChar(rune(expr[i])) {
+				i++
+			}
+			toks = append(toks, expr[start:i])
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+	return toks, nil
+}
+
+func isIdentChar(r rune) bool {
+	return r == '_' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// condParser - a recursive-descent parser/evaluator for conditions
+type condParser struct {
+	toks []string
+	pos  int
+}
+
+func (p *condParser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return ""
+}
+
+func (p *condParser) or() (bool, error) {
+	v, err := p.and()
+	for err == nil && p.peek() == "||" {
+		p.pos++
+		var r bool
+		r, err = p.and()
+		v = v || r
+	}
+	return v, err
+}
+
+func (p *condParser) and() (bool, error) {
+	v, err := p.unary()
+	for err == nil && p.peek() == "&&" {
+		p.pos++
+		var r bool
+		r, err = p.unary()
+		v = v && r
+	}
+	return v, err
+}
+
+func (p *condParser) unary() (bool, error) {
+	switch p.peek() {
+	case "!":
+		p.pos++
+		v, err := p.unary()
+		return !v, err
+	case "(":
+		p.pos++
+		v, err := p.or()
+		if err != nil {
+			return false, err
+		}
+		if p.peek() != ")" {
+			return false, fmt.Errorf("missing ')'")
+		}
+		p.pos++
+		return v, nil
+	}
+	l, err := p.operand()
+	if err != nil {
+		return false, err
+	}
+	op := p.peek()
+	if op != "==" && op != "!=" {
+		return l != "", nil
+	}
+	p.pos++
+	r, err := p.operand()
+	if err != nil {
+		return false, err
+	}
+	return (l == r) == (op == "=="), nil
+}
+
+// operand - the value of a quoted string or variable
+func (p *condParser) operand() (string, error) {
+	t := p.peek()
+	p.pos++
+	switch {
+	case t == "":
+		return "", fmt.Errorf("unexpected end of condition")
+	case t[0] == '"' || t[0] == '\'':
+		return t[1 : len(t)-1], nil
+	case t == "os":
+		return goos, nil
+	case t == "arch":
+		return goarch, nil
+	case strings.HasPrefix(t, "env.") && len(t) > 4:
+		return os.Getenv(t[4:]), nil
+	case isIdentChar(rune(t[0])):
+		return "", fmt.Errorf("unknown variable %q (must be os, arch, or env.NAME)", t)
+	default:
+		return "", fmt.Errorf("unexpected %q", t)
+	}
+}
+
+// applyConditions - remove datasources, context entries, and plugins whose
+// 'when' conditions aren't met, in this config and its profiles
+func (c *Config) applyConditions() error {
+	var err error
+	c.DataSources, err = c.DataSources.withConditions("datasources")
+	if err != nil {
+		return err
+	}
+	c.Context, err = c.Context.withConditions("context")
+	if err != nil {
+		return err
+	}
+	for k, p := range c.Plugins {
+		if p.When != "" {
+			ok, err := evalCondition(p.When)
+			if err != nil {
+				return fmt.Errorf("plugin '%s': %w", k, err)
+			}
+			if !ok {
+				delete(c.Plugins, k)
+			}
+		}
+	}
+	for k, p := range c.Profiles {
+		if p == nil {
+			continue
+		}
+		if p.When != "" {
+			if _, err := evalCondition(p.When); err != nil {
+				return fmt.Errorf("profile '%s': %w", k, err)
+			}
+		}
+		if err := p.applyConditions(); err != nil {
+			return fmt.Errorf("profile '%s': %w", k, err)
+		}
+	}
+	return nil
+}
+
+func (d DSources) withConditions(name string) (DSources, error) {
+	for k, ds := range d {
+		if ds.When == "" {
+			continue
+		}
+		ok, err := evalCondition(ds.When)
+		if err != nil {
+			return nil, fmt.Errorf("%s '%s': %w", name, k, err)
+		}
+		if !ok {
+			delete(d, k)
+		}
+	}
+	return d, nil
+}
+
+// conditionalProfiles - the names of the profiles (other than the named one)
+// whose 'when' conditions are true, in sorted order
+func (c *Config) conditionalProfiles(name string) ([]string, error) {
+	names := []string{}
+	for k, p := range c.Profiles {
+		if p == nil || p.When == "" || k == name {
+			continue
+		}
+		ok, err := evalCondition(p.When)
+		if err != nil {
+			return nil, fmt.Errorf("profile '%s': %w", k, err)
+		}
+		if ok {
+			if len(p.Profiles) > 0 {
+				return nil, fmt.Errorf("profile %q must not define nested profiles", k)
+			}
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setPlatform - override the OS and arch, returning a func to restore them
+func setPlatform(o, a string) func() {
+	origOS, origArch := goos, goarch
+	goos, goarch = o, a
+	return func() { goos, goarch = origOS, origArch }
+}
+
+func TestEvalCondition(t *testing.T) {
+	defer setPlatform("linux", "arm64")()
+	os.Setenv("WHEN_TEST", "yes")
+	defer os.Unsetenv("WHEN_TEST")
+
+	testdata := []struct {
+		expr     string
+		expected bool
+	}{
+		{`os == "linux"`, true},
+		{`os == 'windows'`, false},
+		{`os != "windows"`, true},
+		{`"arm64" == arch`, true},
+		{`os == "linux" && arch == "amd64"`, false},
+		{`os == "windows" || arch == "arm64"`, true},
+		{`os == "linux" && (arch == "amd64" || arch == "arm64")`, true},
+		{`!(os == "linux")`, false},
+		{`env.WHEN_TEST`, true},
+		{`!env.WHEN_TEST_UNSET`, true},
+		{`env.WHEN_TEST == "yes" && !env.WHEN_TEST_UNSET`, true},
+		{`os=="linux"&&arch!="arm64"`, false},
+	}
+	for _, d := range testdata {
+		v, err := evalCondition(d.expr)
+		assert.NoError(t, err, d.expr)
+		assert.Equal(t, d.expected, v, d.expr)
+	}
+
+	errs := []struct {
+		expr, err string
+	}{
+		{``, `invalid condition "": unexpected end of condition`},
+		{`os ==`, `invalid condition "os ==": unexpected end of condition`},
+		{`os == "linux`, `invalid condition "os == \"linux": unterminated string`},
+		{`platform == "linux"`, `invalid condition "platform == \"linux\"": unknown variable "platform" (must be os, arch, or env.NAME)`},
+		{`(os == "linux"`, `invalid condition "(os == \"linux\"": missing ')'`},
+		{`os == "linux")`, `invalid condition "os == \"linux\")": unexpected ")"`},
+		{`os = "linux"`, `invalid condition "os = \"linux\"": unexpected character '='`},
+	}
+	for _, d := range errs {
+		_, err := evalCondition(d.expr)
+		assert.EqualError(t, err, d.err, d.expr)
+	}
+}
+
+func TestParseConditions(t *testing.T) {
+	defer setPlatform("windows", "amd64")()
+
+	cfg, err := Parse(strings.NewReader(`datasources:
+  data:
+    url: file:///c:/data.json
+    when: os == "windows"
+  unixdata:
+    url: file:///etc/data.json
+    when: os != "windows"
+context:
+  cfg:
+    url: file:///c:/cfg.json
+    when: arch == "amd64"
+plugins:
+  figlet:
+    cmd: figlet.exe
+    when: os == "windows"
+  lolcat:
+    cmd: lolcat
+    when: os == "linux"
+  echo: /bin/echo
+profiles:
+  ci:
+    datasources:
+      ci:
+        url: https://ci.example.com/data.json
+        when: os == "darwin"
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, DSources{
+		"data": {URL: mustURL("file:///c:/data.json"), When: `os == "windows"`},
+	}, cfg.DataSources)
+	assert.Equal(t, DSources{
+		"cfg": {URL: mustURL("file:///c:/cfg.json"), When: `arch == "amd64"`},
+	}, cfg.Context)
+	assert.Equal(t, map[string]PluginConfig{
+		"figlet": {Cmd: "figlet.exe", When: `os == "windows"`},
+		"echo":   {Cmd: "/bin/echo"},
+	}, cfg.Plugins)
+	assert.Empty(t, cfg.Profiles["ci"].DataSources)
+
+	_, err = Parse(strings.NewReader(`datasources:
+  data:
+    url: file:///data.json
+    when: os = "windows"
+`))
+	assert.EqualError(t, err, `datasources 'data': invalid condition "os = \"windows\"": unexpected character '='`)
+
+	_, err = Parse(strings.NewReader(`profiles:
+  win:
+    when: bogus
+`))
+	assert.EqualError(t, err, `profile 'win': invalid condition "bogus": unknown variable "bogus" (must be os, arch, or env.NAME)`)
+}
+
+func TestApplyConditionalProfiles(t *testing.T) {
+	defer setPlatform("windows", "amd64")()
+
+	parse := func() *Config {
+		cfg, err := Parse(strings.NewReader(`outputDir: out/
+profiles:
+  windows:
+    when: os == "windows"
+    outputDir: out\win\
+    leftDelim: "<<"
+  x64:
+    when: arch == "amd64"
+    outputDir: out/x64/
+  linux:
+    when: os == "linux"
+    rightDelim: ">>"
+  prod:
+    outputDir: out/prod/
+`))
+		assert.NoError(t, err)
+		return cfg
+	}
+
+	out, err := parse().ApplyProfile("")
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{OutputDir: "out/x64/", LDelim: "<<"}, out)
+
+	out, err = parse().ApplyProfile("prod")
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{OutputDir: "out/prod/", LDelim: "<<"}, out)
+
+	// an explicitly-selected profile is applied regardless of its condition
+	out, err = parse().ApplyProfile("linux")
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{OutputDir: "out/x64/", LDelim: "<<", RDelim: ">>"}, out)
+
+	cfg := &Config{Input: "hi", OutputFiles: []string{"-"}, When: `os == "windows"`}
+	assert.EqualError(t, cfg.Validate(), "'when' may only be set on profiles")
+}
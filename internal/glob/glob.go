@@ -0,0 +1,206 @@
+// Package glob implements shell-style file name patterns extended with
+// recursive "**" wildcards and "{a,b}" alternatives.
+package glob
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// ExpandBraces expands the "{a,b,c}" alternatives in the pattern, returning
+// every combination. Alternatives may be nested, and braces can be escaped
+// with a backslash. Patterns without alternatives are returned as-is.
+func ExpandBraces(pattern string) ([]string, error) {
+	start, end, alts, err := firstBraceGroup(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if start < 0 {
+		return []string{pattern}, nil
+	}
+	out := []string{}
+	for _, alt := range alts {
+		// expanding the result again handles nested and subsequent groups
+		expanded, err := ExpandBraces(pattern[:start] + alt + pattern[end+1:])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, expanded...)
+	}
+	return out, nil
+}
+
+// firstBraceGroup - find the first top-level brace group in the pattern,
+// returning its bounds and the alternatives it contains
+func firstBraceGroup(pattern string) (start, end int, alts []string, err error) {
+	start = -1
+	depth := 0
+	last := 0
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '{':
+			if depth == 0 {
+				start = i
+				last = i + 1
+			}
+			depth++
+		case ',':
+			if depth == 1 {
+				alts = append(alts, pattern[last:i])
+				last = i + 1
+			}
+		case '}':
+			if depth == 0 {
+				return -1, -1, nil, path.ErrBadPattern
+			}
+			depth--
+			if depth == 0 {
+				return start, i, append(alts, pattern[last:i]), nil
+			}
+		}
+	}
+	if depth > 0 {
+		return -1, -1, nil, path.ErrBadPattern
+	}
+	return -1, -1, nil, nil
+}
+
+// Match reports whether the slash-separated name matches the pattern. In
+// addition to the syntax supported by path.Match, a "**" path segment matches
+// zero or more directories, and "{a,b}" matches either alternative.
+func Match(pattern, name string) (bool, error) {
+	patterns, err := ExpandBraces(pattern)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range patterns {
+		ok, err := matchSegments(strings.Split(p, "/"), strings.Split(name, "/"))
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+func matchSegments(pattern, name []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// collapse consecutive "**" segments
+			for len(pattern) > 1 && pattern[1] == "**" {
+				pattern = pattern[1:]
+			}
+			for i := 0; i <= len(name); i++ {
+				ok, err := matchSegments(pattern[1:], name[i:])
+				if err != nil || ok {
+					return ok, err
+				}
+			}
+			return false, nil
+		}
+		if len(name) == 0 {
+			return false, nil
+		}
+		ok, err := path.Match(pattern[0], name[0])
+		if err != nil || !ok {
+			return false, err
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0, nil
+}
+
+// Glob returns the names of all files in the filesystem matching the pattern
+// (see Match for the syntax), in lexical order. As with filepath.Glob, I/O
+// errors are ignored, and the only possible error is ErrBadPattern.
+func Glob(fs afero.Fs, pattern string) ([]string, error) {
+	patterns, err := ExpandBraces(filepath.ToSlash(pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]struct{}{}
+	for _, p := range patterns {
+		// validate the pattern before touching the filesystem
+		for _, seg := range strings.Split(p, "/") {
+			if _, err := path.Match(seg, ""); err != nil {
+				return nil, err
+			}
+		}
+		for _, m := range glob(fs, p) {
+			found[m] = struct{}{}
+		}
+	}
+
+	out := make([]string, 0, len(found))
+	for m := range found {
+		out = append(out, m)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// glob - match a single brace-free, slash-separated pattern
+func glob(fs afero.Fs, pattern string) []string {
+	segs := strings.Split(pattern, "/")
+
+	// find the longest leading run of literal segments, which is the
+	// directory to start walking from
+	n := 0
+	for n < len(segs) && !hasMeta(segs[n]) {
+		n++
+	}
+	if n == len(segs) {
+		if _, err := fs.Stat(filepath.FromSlash(pattern)); err != nil {
+			return nil
+		}
+		return []string{filepath.FromSlash(pattern)}
+	}
+	base := strings.Join(segs[:n], "/")
+	switch {
+	case n == 0:
+		base = "."
+	case base == "":
+		base = "/"
+	}
+
+	// without "**" the depth is bounded by the number of segments
+	maxDepth := -1
+	if !strings.Contains(pattern, "**") {
+		maxDepth = len(segs) - n
+	}
+
+	out := []string{}
+	root := filepath.FromSlash(base)
+	_ = afero.Walk(fs, root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if maxDepth >= 0 && strings.Count(rel, "/")+1 > maxDepth {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ok, _ := matchSegments(segs[n:], strings.Split(rel, "/")); ok {
+			out = append(out, p)
+		}
+		return nil
+	})
+	return out
+}
+
+func hasMeta(s string) bool {
+	return strings.ContainsAny(s, `*?[\`)
+}
@@ -0,0 +1,105 @@
+package glob
+
+import (
+	"path"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandBraces(t *testing.T) {
+	data := []struct {
+		in  string
+		out []string
+	}{
+		{"", []string{""}},
+		{"*.yaml", []string{"*.yaml"}},
+		{"*.{yaml,yml}", []string{"*.yaml", "*.yml"}},
+		{"{a,b}/{c,d}", []string{"a/c", "a/d", "b/c", "b/d"}},
+		{"x{a,b{c,d}}", []string{"xa", "xbc", "xbd"}},
+		{"{,a}b", []string{"b", "ab"}},
+		{`\{a,b\}`, []string{`\{a,b\}`}},
+	}
+	for _, d := range data {
+		out, err := ExpandBraces(d.in)
+		assert.NoError(t, err)
+		assert.Equal(t, d.out, out, d.in)
+	}
+
+	for _, in := range []string{"{a,b", "a}", "{a,{b}"} {
+		_, err := ExpandBraces(in)
+		assert.Equal(t, path.ErrBadPattern, err, in)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	data := []struct {
+		pattern, name string
+		ok            bool
+	}{
+		{"*.yaml", "a.yaml", true},
+		{"*.yaml", "x/a.yaml", false},
+		{"**/*.yaml", "a.yaml", true},
+		{"**/*.yaml", "x/y/a.yaml", true},
+		{"**/*.yaml", "x/y/a.json", false},
+		{"x/**", "x", true},
+		{"x/**", "x/y/z", true},
+		{"x/**/z", "x/z", true},
+		{"x/**/**/z", "x/a/b/z", true},
+		{"x/**/z", "x/a/b/y", false},
+		{"**/*.{yaml,yml}", "a/b.yml", true},
+		{"{vendor,node_modules}/**", "node_modules/a/b", true},
+		{"{vendor,node_modules}/**", "src/a", false},
+	}
+	for _, d := range data {
+		ok, err := Match(d.pattern, d.name)
+		assert.NoError(t, err)
+		assert.Equal(t, d.ok, ok, "%s ~ %s", d.pattern, d.name)
+	}
+
+	_, err := Match("[", "a")
+	assert.Error(t, err)
+	_, err = Match("{a", "a")
+	assert.Error(t, err)
+}
+
+func TestGlob(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for _, f := range []string{
+		"/in/a.yaml",
+		"/in/b.yml",
+		"/in/c.json",
+		"/in/sub/d.yaml",
+		"/in/sub/deeper/e.yaml",
+		"/in/vendor/f.yaml",
+	} {
+		_ = afero.WriteFile(fs, f, []byte{}, 0644)
+	}
+
+	data := []struct {
+		pattern string
+		out     []string
+	}{
+		{"/in/*.yaml", []string{"/in/a.yaml"}},
+		{"/in/*.{yaml,yml}", []string{"/in/a.yaml", "/in/b.yml"}},
+		{"/in/**/*.yaml", []string{"/in/a.yaml", "/in/sub/d.yaml", "/in/sub/deeper/e.yaml", "/in/vendor/f.yaml"}},
+		{"/in/{sub,vendor}/*.yaml", []string{"/in/sub/d.yaml", "/in/vendor/f.yaml"}},
+		{"/in/*/*.yaml", []string{"/in/sub/d.yaml", "/in/vendor/f.yaml"}},
+		{"/in/c.json", []string{"/in/c.json"}},
+		{"/in/nope.json", []string{}},
+		{"/nope/**", []string{}},
+		// overlapping alternatives must not produce duplicates
+		{"/in/{*,a}.yaml", []string{"/in/a.yaml"}},
+	}
+	for _, d := range data {
+		out, err := Glob(fs, d.pattern)
+		assert.NoError(t, err)
+		assert.Equal(t, d.out, out, d.pattern)
+	}
+
+	_, err := Glob(fs, "/in/[")
+	assert.Error(t, err)
+	_, err = Glob(fs, "/in/{a")
+	assert.Error(t, err)
+}
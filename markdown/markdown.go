@@ -0,0 +1,45 @@
+// Package markdown contains functions for rendering Markdown
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+// Options - options for rendering Markdown
+type Options struct {
+	// GFM - enable the GitHub Flavored Markdown extensions: tables,
+	// strikethrough, autolinks, and task lists
+	GFM bool
+	// Unsafe - render raw HTML and potentially dangerous links (like
+	// "javascript:" URLs) instead of omitting them
+	Unsafe bool
+	// HardWraps - render newlines within paragraphs as <br> elements
+	HardWraps bool
+}
+
+// ToHTML - render CommonMark-formatted Markdown as HTML
+func ToHTML(in []byte, opts Options) ([]byte, error) {
+	exts := []goldmark.Extender{}
+	if opts.GFM {
+		exts = append(exts, extension.GFM)
+	}
+	rOpts := []renderer.Option{}
+	if opts.Unsafe {
+		rOpts = append(rOpts, html.WithUnsafe())
+	}
+	if opts.HardWraps {
+		rOpts = append(rOpts, html.WithHardWraps())
+	}
+	md := goldmark.New(
+		goldmark.WithExtensions(exts...),
+		goldmark.WithRendererOptions(rOpts...),
+	)
+	out := &bytes.Buffer{}
+	err := md.Convert(in, out)
+	return out.Bytes(), err
+}
@@ -0,0 +1,33 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToHTML(t *testing.T) {
+	data := []struct {
+		in       string
+		opts     Options
+		expected string
+	}{
+		{"", Options{}, ""},
+		{"# Hello\n\n*world*", Options{}, "<h1>Hello</h1>\n<p><em>world</em></p>\n"},
+		{"a\nb", Options{}, "<p>a\nb</p>\n"},
+		{"a\nb", Options{HardWraps: true}, "<p>a<br>\nb</p>\n"},
+		{"<b>hi</b>", Options{}, "<p><!-- raw HTML omitted -->hi<!-- raw HTML omitted --></p>\n"},
+		{"<b>hi</b>", Options{Unsafe: true}, "<p><b>hi</b></p>\n"},
+		{"<div>\nhi\n</div>", Options{}, "<!-- raw HTML omitted -->\n"},
+		{"[x](javascript:alert(1))", Options{}, "<p><a href=\"\">x</a></p>\n"},
+		{"~~old~~ https://example.com", Options{}, "<p>~~old~~ https://example.com</p>\n"},
+		{"~~old~~ https://example.com", Options{GFM: true}, "<p><del>old</del> <a href=\"https://example.com\">https://example.com</a></p>\n"},
+		{"| a |\n|---|\n| 1 |", Options{GFM: true}, "<table>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>1</td>\n</tr>\n</tbody>\n</table>\n"},
+		{"- [x] done", Options{GFM: true}, "<ul>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> done</li>\n</ul>\n"},
+	}
+	for _, d := range data {
+		out, err := ToHTML([]byte(d.in), d.opts)
+		assert.NoError(t, err)
+		assert.Equal(t, d.expected, string(out), d.in)
+	}
+}
@@ -0,0 +1,27 @@
+package net
+
+import (
+	"golang.org/x/net/idna"
+)
+
+// idnProfile - the same as idna.Lookup, but allowing characters like "*"
+// and "_", which are common in wildcard certificates and DNS records
+var idnProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.Transitional(false),
+	idna.StrictDomainName(false),
+	idna.VerifyDNSLength(true),
+)
+
+// ToASCII - convert an internationalized domain name to its ASCII
+// ("punycode") form, e.g. "bücher.example" becomes "xn--bcher-kva.example"
+func ToASCII(name string) (string, error) {
+	return idnProfile.ToASCII(name)
+}
+
+// ToUnicode - convert a domain name from its ASCII ("punycode") form to
+// Unicode, e.g. "xn--bcher-kva.example" becomes "bücher.example"
+func ToUnicode(name string) (string, error) {
+	return idnProfile.ToUnicode(name)
+}
@@ -0,0 +1,48 @@
+package net
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToASCII(t *testing.T) {
+	data := []struct {
+		in, expected string
+	}{
+		{"example.com", "example.com"},
+		{"bücher.example", "xn--bcher-kva.example"},
+		{"Bücher.Example", "xn--bcher-kva.example"},
+		{"*.bücher.example", "*.xn--bcher-kva.example"},
+		{"_dmarc.bücher.example.", "_dmarc.xn--bcher-kva.example."},
+		{"日本語.jp", "xn--wgv71a119e.jp"},
+		{"xn--bcher-kva.example", "xn--bcher-kva.example"},
+	}
+	for _, d := range data {
+		out, err := ToASCII(d.in)
+		assert.NoError(t, err, d.in)
+		assert.Equal(t, d.expected, out, d.in)
+	}
+
+	_, err := ToASCII("a..b")
+	assert.Error(t, err)
+}
+
+func TestToUnicode(t *testing.T) {
+	data := []struct {
+		in, expected string
+	}{
+		{"example.com", "example.com"},
+		{"xn--bcher-kva.example", "bücher.example"},
+		{"*.xn--bcher-kva.example", "*.bücher.example"},
+		{"xn--wgv71a119e.jp", "日本語.jp"},
+	}
+	for _, d := range data {
+		out, err := ToUnicode(d.in)
+		assert.NoError(t, err, d.in)
+		assert.Equal(t, d.expected, out, d.in)
+	}
+
+	_, err := ToUnicode("xn--a.example")
+	assert.Error(t, err)
+}
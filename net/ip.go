@@ -0,0 +1,92 @@
+package net
+
+import (
+	"math/big"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// MaxRangeSize - the maximum number of addresses IPRange will return
+const MaxRangeSize = 1 << 16
+
+var maxIPv4 = big.NewInt(0xffffffff)
+
+// normalize - IPv4 addresses are converted to their 4-byte form
+func normalize(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip
+}
+
+func validIP(ip net.IP) bool {
+	return len(ip) == net.IPv4len || len(ip) == net.IPv6len
+}
+
+// IPToInt - convert the IP address to an integer
+func IPToInt(ip net.IP) *big.Int {
+	return new(big.Int).SetBytes(normalize(ip))
+}
+
+// IntToIP - convert an integer to an IP address. Values that fit in 32 bits
+// are converted to IPv4 addresses, and larger values to IPv6 addresses.
+func IntToIP(i *big.Int) (net.IP, error) {
+	if i.Sign() < 0 {
+		return nil, errors.Errorf("invalid IP address %s: must not be negative", i)
+	}
+	if i.Cmp(maxIPv4) <= 0 {
+		return toIP(i, net.IPv4len), nil
+	}
+	if i.BitLen() > 8*net.IPv6len {
+		return nil, errors.Errorf("invalid IP address %s: too large", i)
+	}
+	return toIP(i, net.IPv6len), nil
+}
+
+func toIP(i *big.Int, size int) net.IP {
+	b := i.Bytes()
+	ip := make(net.IP, size)
+	copy(ip[size-len(b):], b)
+	return ip
+}
+
+// AddIP - add delta to the IP address, which may be negative. The result must
+// be in the same family (IPv4 or IPv6) as ip.
+func AddIP(ip net.IP, delta int64) (net.IP, error) {
+	ip = normalize(ip)
+	if !validIP(ip) {
+		return nil, errors.Errorf("invalid IP address %v", ip)
+	}
+	i := IPToInt(ip)
+	i.Add(i, big.NewInt(delta))
+	if i.Sign() < 0 || i.BitLen() > 8*len(ip) {
+		return nil, errors.Errorf("%v %+d is out of range", ip, delta)
+	}
+	return toIP(i, len(ip)), nil
+}
+
+// IPRange - all IP addresses from start to end, inclusive. Both must be in
+// the same family, and there may be at most MaxRangeSize addresses.
+func IPRange(start, end net.IP) ([]net.IP, error) {
+	start, end = normalize(start), normalize(end)
+	if !validIP(start) || !validIP(end) {
+		return nil, errors.Errorf("invalid range %v-%v", start, end)
+	}
+	if len(start) != len(end) {
+		return nil, errors.Errorf("invalid range %v-%v: addresses must both be IPv4 or IPv6", start, end)
+	}
+	s, e := IPToInt(start), IPToInt(end)
+	size := new(big.Int).Sub(e, s)
+	if size.Sign() < 0 {
+		return nil, errors.Errorf("invalid range %v-%v: start must not be after end", start, end)
+	}
+	if size.Cmp(big.NewInt(MaxRangeSize)) >= 0 {
+		return nil, errors.Errorf("invalid range %v-%v: too many addresses (max %d)", start, end, MaxRangeSize)
+	}
+	out := make([]net.IP, 0, size.Int64()+1)
+	for i := s; i.Cmp(e) <= 0; i = new(big.Int).Add(i, big.NewInt(1)) {
+		out = append(out, toIP(i, len(start)))
+	}
+	return out, nil
+}
@@ -0,0 +1,100 @@
+package net
+
+import (
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPToInt(t *testing.T) {
+	assert.Equal(t, "167772161", IPToInt(net.ParseIP("10.0.0.1")).String())
+	assert.Equal(t, "4294967295", IPToInt(net.ParseIP("255.255.255.255")).String())
+	assert.Equal(t, "1", IPToInt(net.ParseIP("::1")).String())
+	assert.Equal(t, "42540766411282592856903984951653826561", IPToInt(net.ParseIP("2001:db8::1")).String())
+}
+
+func TestIntToIP(t *testing.T) {
+	data := []struct {
+		in       string
+		expected string
+	}{
+		{"0", "0.0.0.0"},
+		{"167772161", "10.0.0.1"},
+		{"4294967295", "255.255.255.255"},
+		{"4294967296", "::1:0:0"},
+		{"42540766411282592856903984951653826561", "2001:db8::1"},
+		{"340282366920938463463374607431768211455", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+	}
+	for _, d := range data {
+		i, _ := new(big.Int).SetString(d.in, 10)
+		ip, err := IntToIP(i)
+		assert.NoError(t, err)
+		assert.Equal(t, d.expected, ip.String())
+	}
+
+	_, err := IntToIP(big.NewInt(-1))
+	assert.Error(t, err)
+
+	i, _ := new(big.Int).SetString("340282366920938463463374607431768211456", 10)
+	_, err = IntToIP(i)
+	assert.Error(t, err)
+}
+
+func TestAddIP(t *testing.T) {
+	data := []struct {
+		ip       string
+		delta    int64
+		expected string
+	}{
+		{"10.0.0.1", 1, "10.0.0.2"},
+		{"10.0.0.255", 1, "10.0.1.0"},
+		{"10.0.1.0", -1, "10.0.0.255"},
+		{"10.0.0.1", 300, "10.0.1.45"},
+		{"2001:db8::ffff", 1, "2001:db8::1:0"},
+		{"::ffff:10.0.0.1", 1, "10.0.0.2"},
+	}
+	for _, d := range data {
+		ip, err := AddIP(net.ParseIP(d.ip), d.delta)
+		assert.NoError(t, err)
+		assert.Equal(t, d.expected, ip.String())
+	}
+
+	_, err := AddIP(net.ParseIP("255.255.255.255"), 1)
+	assert.Error(t, err)
+	_, err = AddIP(net.ParseIP("0.0.0.0"), -1)
+	assert.Error(t, err)
+	_, err = AddIP(net.ParseIP("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"), 1)
+	assert.Error(t, err)
+	_, err = AddIP(nil, 1)
+	assert.Error(t, err)
+}
+
+func TestIPRange(t *testing.T) {
+	ips, err := IPRange(net.ParseIP("10.0.0.254"), net.ParseIP("10.0.1.1"))
+	assert.NoError(t, err)
+	out := []string{}
+	for _, ip := range ips {
+		out = append(out, ip.String())
+	}
+	assert.Equal(t, []string{"10.0.0.254", "10.0.0.255", "10.0.1.0", "10.0.1.1"}, out)
+
+	ips, err = IPRange(net.ParseIP("2001:db8::1"), net.ParseIP("2001:db8::1"))
+	assert.NoError(t, err)
+	assert.Len(t, ips, 1)
+	assert.Equal(t, "2001:db8::1", ips[0].String())
+
+	ips, err = IPRange(net.ParseIP("10.0.0.0"), net.ParseIP("10.0.255.255"))
+	assert.NoError(t, err)
+	assert.Len(t, ips, MaxRangeSize)
+
+	_, err = IPRange(net.ParseIP("10.0.0.0"), net.ParseIP("10.1.0.0"))
+	assert.Error(t, err)
+	_, err = IPRange(net.ParseIP("10.0.0.2"), net.ParseIP("10.0.0.1"))
+	assert.Error(t, err)
+	_, err = IPRange(net.ParseIP("10.0.0.1"), net.ParseIP("::2"))
+	assert.Error(t, err)
+	_, err = IPRange(nil, nil)
+	assert.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"text/template"
 	"time"
 
@@ -21,8 +22,14 @@ func bindPlugins(ctx context.Context, cfg *config.Config, funcMap template.FuncM
 		plugin := &plugin{
 			ctx:     ctx,
 			name:    k,
-			path:    v,
+			path:    v.Cmd,
+			args:    v.Args,
 			timeout: cfg.PluginTimeout,
+			env:     v.Env,
+			stderr:  v.Stderr,
+		}
+		if v.Timeout != 0 {
+			plugin.timeout = v.Timeout
 		}
 		if _, ok := funcMap[plugin.name]; ok {
 			return fmt.Errorf("function %q is already bound, and can not be overridden", plugin.name)
@@ -35,8 +42,13 @@ func bindPlugins(ctx context.Context, cfg *config.Config, funcMap template.FuncM
 // plugin represents a custom function that binds to an external process to be executed
 type plugin struct {
 	name, path string
+	args       []string
 	timeout    time.Duration
 	ctx        context.Context
+	// environment variables to pass - all when nil
+	env []string
+	// "inherit" (or ""), "discard", or "capture"
+	stderr string
 }
 
 // builds a command that's appropriate for running scripts
@@ -69,8 +81,22 @@ func findPowershell() string {
 	return "pwsh"
 }
 
+// environ - the environment for the plugin, filtered to the allowed names
+func (p *plugin) environ() []string {
+	if p.env == nil {
+		return nil
+	}
+	env := []string{}
+	for _, k := range p.env {
+		if v, ok := os.LookupEnv(k); ok {
+			env = append(env, k+"="+v)
+		}
+	}
+	return env
+}
+
 func (p *plugin) run(args ...interface{}) (interface{}, error) {
-	a := conv.ToStrings(args...)
+	a := append(append([]string{}, p.args...), conv.ToStrings(args...)...)
 
 	name, a := p.buildCommand(a)
 
@@ -78,7 +104,16 @@ func (p *plugin) run(args ...interface{}) (interface{}, error) {
 	defer cancel()
 	c := exec.CommandContext(ctx, name, a...)
 	c.Stdin = nil
-	c.Stderr = os.Stderr
+	c.Env = p.environ()
+	errBuf := &bytes.Buffer{}
+	switch p.stderr {
+	case "discard":
+		c.Stderr = nil
+	case "capture":
+		c.Stderr = errBuf
+	default:
+		c.Stderr = os.Stderr
+	}
 	outBuf := &bytes.Buffer{}
 	c.Stdout = outBuf
 
@@ -99,6 +134,8 @@ func (p *plugin) run(args ...interface{}) (interface{}, error) {
 
 	if ctx.Err() != nil {
 		err = fmt.Errorf("plugin timed out after %v: %w", elapsed, ctx.Err())
+	} else if err != nil && errBuf.Len() > 0 {
+		err = fmt.Errorf("%w: %s", err, strings.TrimSpace(errBuf.String()))
 	}
 
 	return outBuf.String(), err
@@ -2,8 +2,11 @@ package gomplate
 
 import (
 	"context"
+	"os"
+	"runtime"
 	"testing"
 	"text/template"
+	"time"
 
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/assert/cmp"
@@ -15,13 +18,13 @@ func TestBindPlugins(t *testing.T) {
 	ctx := context.TODO()
 	fm := template.FuncMap{}
 	cfg := &config.Config{
-		Plugins: map[string]string{},
+		Plugins: map[string]config.PluginConfig{},
 	}
 	err := bindPlugins(ctx, cfg, fm)
 	assert.NilError(t, err)
 	assert.DeepEqual(t, template.FuncMap{}, fm)
 
-	cfg.Plugins = map[string]string{"foo": "bar"}
+	cfg.Plugins = map[string]config.PluginConfig{"foo": {Cmd: "bar"}}
 	err = bindPlugins(ctx, cfg, fm)
 	assert.NilError(t, err)
 	assert.Check(t, cmp.Contains(fm, "foo"))
@@ -54,3 +57,50 @@ func TestBuildCommand(t *testing.T) {
 		assert.DeepEqual(t, d.expected, actual)
 	}
 }
+
+func TestPluginEnviron(t *testing.T) {
+	os.Setenv("PLUGIN_TEST_FOO", "foo")
+	defer os.Unsetenv("PLUGIN_TEST_FOO")
+
+	p := &plugin{}
+	assert.Assert(t, p.environ() == nil)
+
+	p.env = []string{}
+	assert.DeepEqual(t, []string{}, p.environ())
+
+	p.env = []string{"PLUGIN_TEST_FOO", "PLUGIN_TEST_MISSING"}
+	assert.DeepEqual(t, []string{"PLUGIN_TEST_FOO=foo"}, p.environ())
+}
+
+func TestPluginRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	os.Setenv("PLUGIN_TEST_FOO", "foo")
+	defer os.Unsetenv("PLUGIN_TEST_FOO")
+
+	p := &plugin{
+		ctx:     context.Background(),
+		name:    "test",
+		path:    "sh",
+		args:    []string{"-c", `echo "$0 $1 [$PLUGIN_TEST_FOO]"`},
+		timeout: 5 * time.Second,
+	}
+	out, err := p.run("bar", "baz")
+	assert.NilError(t, err)
+	assert.Equal(t, "bar baz [foo]\n", out)
+
+	p.env = []string{}
+	out, err = p.run("bar", "baz")
+	assert.NilError(t, err)
+	assert.Equal(t, "bar baz []\n", out)
+
+	p.args = []string{"-c", "echo oops >&2; exit 1"}
+	p.stderr = "capture"
+	_, err = p.run()
+	assert.ErrorContains(t, err, "exit status 1: oops")
+
+	p.stderr = "discard"
+	_, err = p.run()
+	assert.Error(t, err, "exit status 1")
+}
@@ -1,7 +1,10 @@
 // Package regexp contains functions for dealing with regular expressions
 package regexp
 
-import stdre "regexp"
+import (
+	stdre "regexp"
+	"strings"
+)
 
 // Find -
 func Find(expression, input string) (string, error) {
@@ -21,6 +24,36 @@ func FindAll(expression string, n int, input string) ([]string, error) {
 	return re.FindAllString(input, n), nil
 }
 
+// FindNamed - find the first match, returning a map of named capture groups
+// to their matched values. The map is empty when there's no match.
+func FindNamed(expression, input string) (map[string]string, error) {
+	re, err := stdre.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]string{}
+	m := re.FindStringSubmatch(input)
+	if m == nil {
+		return out, nil
+	}
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			out[name] = m[i]
+		}
+	}
+	return out, nil
+}
+
+// FindAllSubmatch - find up to n matches (all matches when n < 0), each
+// returned as a slice of the full match followed by its capture groups
+func FindAllSubmatch(expression string, n int, input string) ([][]string, error) {
+	re, err := stdre.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	return re.FindAllStringSubmatch(input, n), nil
+}
+
 // Match -
 func Match(expression, input string) bool {
 	re := stdre.MustCompile(expression)
@@ -42,6 +75,40 @@ func ReplaceLiteral(expression, replacement, input string) (string, error) {
 	return re.ReplaceAllLiteralString(input, replacement), nil
 }
 
+// ReplaceFunc - replace each match with the result of calling fn with the
+// match's submatches (the full match followed by the capture groups) and its
+// named capture groups. The first error returned by fn stops the replacement.
+func ReplaceFunc(expression, input string, fn func(groups []string, named map[string]string) (string, error)) (string, error) {
+	re, err := stdre.Compile(expression)
+	if err != nil {
+		return "", err
+	}
+	names := re.SubexpNames()
+	out := &strings.Builder{}
+	last := 0
+	for _, loc := range re.FindAllStringSubmatchIndex(input, -1) {
+		groups := make([]string, len(loc)/2)
+		named := map[string]string{}
+		for i := range groups {
+			if loc[2*i] >= 0 {
+				groups[i] = input[loc[2*i]:loc[2*i+1]]
+			}
+			if names[i] != "" {
+				named[names[i]] = groups[i]
+			}
+		}
+		repl, err := fn(groups, named)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(input[last:loc[0]])
+		out.WriteString(repl)
+		last = loc[1]
+	}
+	out.WriteString(input[last:])
+	return out.String(), nil
+}
+
 // Split -
 func Split(expression string, n int, input string) ([]string, error) {
 	re, err := stdre.Compile(expression)
@@ -1,6 +1,7 @@
 package regexp
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -37,6 +38,75 @@ func TestFindAll(t *testing.T) {
 		assert.EqualValues(t, d.expected, f)
 	}
 }
+
+func TestFindNamed(t *testing.T) {
+	_, err := FindNamed(`[a-`, "")
+	assert.Error(t, err)
+
+	m, err := FindNamed(`(?P<key>\w+)=(?P<value>\w*)`, `a=1 b=2`)
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]string{"key": "a", "value": "1"}, m)
+
+	m, err = FindNamed(`(?P<year>\d{4})-(\d{2})`, `2020-04`)
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]string{"year": "2020"}, m)
+
+	m, err = FindNamed(`(?P<key>\w+)=`, `nothing here`)
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]string{}, m)
+}
+
+func TestFindAllSubmatch(t *testing.T) {
+	_, err := FindAllSubmatch(`[a-`, -1, "")
+	assert.Error(t, err)
+
+	testdata := []struct {
+		re       string
+		n        int
+		in       string
+		expected [][]string
+	}{
+		{`(\w+)=(\w*)`, -1, `a=1 b= c=3`, [][]string{{"a=1", "a", "1"}, {"b=", "b", ""}, {"c=3", "c", "3"}}},
+		{`(\w+)=(\w*)`, 1, `a=1 b= c=3`, [][]string{{"a=1", "a", "1"}}},
+		{`(\w+)=(\w*)`, 0, `a=1 b= c=3`, nil},
+		{`(\w+)=(\w*)`, -1, `nothing here`, nil},
+	}
+
+	for _, d := range testdata {
+		f, err := FindAllSubmatch(d.re, d.n, d.in)
+		assert.NoError(t, err)
+		assert.EqualValues(t, d.expected, f)
+	}
+}
+
+func TestReplaceFunc(t *testing.T) {
+	_, err := ReplaceFunc(`[a-`, "", nil)
+	assert.Error(t, err)
+
+	out, err := ReplaceFunc(`v(?P<major>\d+)\.(\d+)`, "v1.2 and v3.4",
+		func(groups []string, named map[string]string) (string, error) {
+			return "<" + groups[0] + "|" + named["major"] + "|" + groups[2] + ">", nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, "<v1.2|1|2> and <v3.4|3|4>", out)
+
+	out, err = ReplaceFunc(`x(y)?`, "axbxyc",
+		func(groups []string, _ map[string]string) (string, error) {
+			return "[" + groups[1] + "]", nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, "a[]b[y]c", out)
+
+	out, err = ReplaceFunc(`z`, "abc", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", out)
+
+	_, err = ReplaceFunc(`b`, "abc", func([]string, map[string]string) (string, error) {
+		return "", fmt.Errorf("boom")
+	})
+	assert.EqualError(t, err, "boom")
+}
+
 func TestMatch(t *testing.T) {
 	assert.True(t, Match(`^[a-z]+\[[0-9]+\]$`, "adam[23]"))
 	assert.True(t, Match(`^[a-z]+\[[0-9]+\]$`, "eve[7]"))
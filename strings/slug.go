@@ -0,0 +1,96 @@
+package strings
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/rainycape/unidecode"
+)
+
+// SlugOpts - options for Slug
+type SlugOpts struct {
+	// Lang - the language to transliterate with, as an ISO 639 code (e.g.
+	// "de" transliterates "ä" as "ae"). Defaults to English.
+	Lang string
+
+	// Separator - the word separator (defaults to "-")
+	Separator string
+
+	// MaxLength - the maximum length. Slugs are truncated at a word
+	// boundary where possible. 0 means no limit.
+	MaxLength int
+
+	// DNS - make the slug a valid DNS label: underscores are also replaced,
+	// and the length is limited to 63 characters
+	DNS bool
+}
+
+// dnsLabelMax - the maximum length of a DNS label (RFC 1035)
+const dnsLabelMax = 63
+
+var multiDashRe = regexp.MustCompile("-+")
+
+// Slug - transliterate the input to ASCII, lowercase it, replace runs of
+// characters that aren't letters, digits, or underscores with a single
+// separator, and trim separators from the ends
+func Slug(in string, opts SlugOpts) string {
+	lang := opts.Lang
+	if lang == "" {
+		lang = "en"
+	}
+	s := slug.MakeLang(in, lang)
+
+	if opts.DNS {
+		s = strings.Trim(multiDashRe.ReplaceAllString(strings.ReplaceAll(s, "_", "-"), "-"), "-")
+		if opts.MaxLength == 0 || opts.MaxLength > dnsLabelMax {
+			opts.MaxLength = dnsLabelMax
+		}
+	}
+
+	if opts.MaxLength > 0 {
+		s = truncateSlug(s, opts.MaxLength)
+	}
+
+	if opts.Separator != "" && opts.Separator != "-" {
+		s = strings.ReplaceAll(s, "-", opts.Separator)
+	}
+	return s
+}
+
+// truncateSlug - truncate at the last word boundary that fits, or mid-word
+// if the first word is too long
+func truncateSlug(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	if i := strings.LastIndex(s[:max+1], "-"); i > 0 {
+		return strings.TrimRight(s[:i], "-_")
+	}
+	return strings.TrimRight(s[:max], "-_")
+}
+
+// translitSubs - language-specific transliterations, applied before the
+// general-purpose ones
+var translitSubs = map[string]*strings.Replacer{
+	"de": strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "Ä", "Ae", "Ö", "Oe", "Ü", "Ue", "ß", "ss"),
+	"da": strings.NewReplacer("æ", "ae", "ø", "oe", "å", "aa", "Æ", "Ae", "Ø", "Oe", "Å", "Aa"),
+}
+
+func init() {
+	translitSubs["deu"] = translitSubs["de"]
+	translitSubs["dan"] = translitSubs["da"]
+	translitSubs["nb"] = translitSubs["da"]
+	translitSubs["nn"] = translitSubs["da"]
+	translitSubs["no"] = translitSubs["da"]
+}
+
+// Transliterate - convert the input to its closest ASCII representation.
+// The optional language (an ISO 639 code) selects language-specific
+// transliterations, such as "ü" to "ue" in German.
+func Transliterate(in, lang string) string {
+	if r, ok := translitSubs[strings.ToLower(lang)]; ok {
+		in = r.Replace(in)
+	}
+	return unidecode.Unidecode(in)
+}
@@ -0,0 +1,50 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlug(t *testing.T) {
+	testdata := []struct {
+		in, expected string
+		opts         SlugOpts
+	}{
+		{"Hello, World!", "hello-world", SlugOpts{}},
+		{"Größe & Gewicht", "grosse-and-gewicht", SlugOpts{}},
+		{"Größe & Gewicht", "groesse-und-gewicht", SlugOpts{Lang: "de"}},
+		{"Ελληνικά κείμενα", "ellenika-keimena", SlugOpts{}},
+		{"日本語", "ri-ben-yu", SlugOpts{}},
+		{"snake_case title", "snake_case-title", SlugOpts{}},
+		{"snake_case title", "snake-case-title", SlugOpts{DNS: true}},
+		{"__private__ thing", "private-thing", SlugOpts{DNS: true}},
+		{"one two three", "one_two_three", SlugOpts{Separator: "_"}},
+		{"one two three", "one-two", SlugOpts{MaxLength: 8}},
+		{"one two three", "one-two", SlugOpts{MaxLength: 7}},
+		{"abcdefghij", "abcde", SlugOpts{MaxLength: 5}},
+		{"a very long title that just keeps going and going and going and going",
+			"a-very-long-title-that-just-keeps-going-and-going-and-going-and", SlugOpts{DNS: true}},
+		{"a very long title", "a-very", SlugOpts{DNS: true, MaxLength: 10}},
+	}
+	for _, d := range testdata {
+		assert.Equal(t, d.expected, Slug(d.in, d.opts), "%q %+v", d.in, d.opts)
+	}
+}
+
+func TestTransliterate(t *testing.T) {
+	testdata := []struct {
+		in, lang, expected string
+	}{
+		{"Größe", "", "Grosse"},
+		{"Größe", "de", "Groesse"},
+		{"Øresund Bro", "", "Oresund Bro"},
+		{"Øresund Bro", "da", "Oeresund Bro"},
+		{"Crème brûlée", "fr", "Creme brulee"},
+		{"Москва", "", "Moskva"},
+		{"plain ascii", "", "plain ascii"},
+	}
+	for _, d := range testdata {
+		assert.Equal(t, d.expected, Transliterate(d.in, d.lang), "%q %q", d.in, d.lang)
+	}
+}
@@ -7,9 +7,13 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
+	"github.com/hairyhenderson/gomplate/v3/data"
+	"github.com/hairyhenderson/gomplate/v3/funcs"
 	"github.com/hairyhenderson/gomplate/v3/internal/config"
+	"github.com/hairyhenderson/gomplate/v3/internal/glob"
 	"github.com/hairyhenderson/gomplate/v3/tmpl"
 
 	"github.com/pkg/errors"
@@ -24,6 +28,7 @@ const gomplateignore = ".gomplateignore"
 // for overriding in tests
 var stdin io.ReadCloser = os.Stdin
 var fs = afero.NewOsFs()
+var readURL = data.ReadURL
 
 // Stdout allows overriding the writer to use when templates are written to stdout ("-").
 var Stdout io.WriteCloser = os.Stdout
@@ -38,11 +43,12 @@ type tplate struct {
 	modeOverride bool
 }
 
-func addTmplFuncs(f template.FuncMap, root *template.Template, ctx interface{}) {
-	t := tmpl.New(root, ctx)
+func addTmplFuncs(f template.FuncMap, root *template.Template, ctx interface{}, reader tmpl.ReaderFunc) {
+	t := tmpl.NewWithReader(root, ctx, reader)
 	tns := func() *tmpl.Template { return t }
 	f["tmpl"] = tns
 	f["tpl"] = t.Inline
+	f["regexp"] = funcs.ReNSWithTemplate(t)
 }
 
 func (t *tplate) toGoTemplate(g *gomplate) (tmpl *template.Template, err error) {
@@ -52,9 +58,9 @@ func (t *tplate) toGoTemplate(g *gomplate) (tmpl *template.Template, err error)
 		tmpl = template.New(t.name)
 		g.rootTemplate = tmpl
 	}
-	tmpl.Option("missingkey=error")
-	// the "tmpl" funcs get added here because they need access to the root template and context
-	addTmplFuncs(g.funcMap, g.rootTemplate, g.tmplctx)
+	tmpl.Option("missingkey=" + g.missingKeyOption())
+	// the "tmpl" funcs (and regexp, for ReplaceFunc) get added here because they need access to the root template and context
+	addTmplFuncs(g.funcMap, g.rootTemplate, g.tmplctx, g.reader)
 	tmpl.Funcs(g.funcMap)
 	tmpl.Delims(g.leftDelim, g.rightDelim)
 	_, err = tmpl.Parse(t.contents)
@@ -62,8 +68,7 @@ func (t *tplate) toGoTemplate(g *gomplate) (tmpl *template.Template, err error)
 		return nil, err
 	}
 	for alias, path := range g.nestedTemplates {
-		// nolint: gosec
-		b, err := ioutil.ReadFile(path)
+		b, err := g.readNested(path)
 		if err != nil {
 			return nil, err
 		}
@@ -75,6 +80,41 @@ func (t *tplate) toGoTemplate(g *gomplate) (tmpl *template.Template, err error)
 	return tmpl, nil
 }
 
+// missingKeyOption - the text/template missingkey option for the mode -
+// "default" mode prints "<no value>" (to be replaced), like "invalid"
+func (g *gomplate) missingKeyOption() string {
+	switch g.missingKey {
+	case "":
+		return "error"
+	case "default":
+		return "invalid"
+	default:
+		return g.missingKey
+	}
+}
+
+// readNested - read a nested template from the filesystem, or from a
+// datasource URL
+func (g *gomplate) readNested(ref string) ([]byte, error) {
+	u, ok := templateURL(ref)
+	if !ok {
+		// nolint: gosec
+		return ioutil.ReadFile(ref)
+	}
+	if b, ok := g.nestedCache[ref]; ok {
+		return b, nil
+	}
+	b, err := readURL(u, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't read nested template %s", ref)
+	}
+	if g.nestedCache == nil {
+		g.nestedCache = map[string][]byte{}
+	}
+	g.nestedCache[ref] = b
+	return b, nil
+}
+
 // loadContents - reads the template in _once_ if it hasn't yet been read. Uses the name!
 func (t *tplate) loadContents() (err error) {
 	if t.contents == "" {
@@ -161,12 +201,17 @@ func walkDir(dir string, outFileNamer func(string) (string, error), excludeGlob
 	}
 	dirMode := dirStat.Mode()
 
+	excludes, err := expandExcludes(excludeGlob)
+	if err != nil {
+		return nil, err
+	}
+
 	templates := make([]*tplate, 0)
 	matcher := xignore.NewMatcher(fs)
 	matches, err := matcher.Matches(dir, &xignore.MatchesOptions{
 		Ignorefile:    gomplateignore,
 		Nested:        true, // allow nested ignorefile
-		AfterPatterns: excludeGlob,
+		AfterPatterns: excludes,
 	})
 	if err != nil {
 		return nil, err
@@ -207,6 +252,27 @@ func walkDir(dir string, outFileNamer func(string) (string, error), excludeGlob
 	return templates, nil
 }
 
+// expandExcludes - expand the {a,b} alternatives in the exclude patterns, which
+// the .gitignore-style matcher doesn't support. Negated patterns (from
+// --include) stay negated.
+func expandExcludes(patterns []string) ([]string, error) {
+	out := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		prefix := ""
+		if strings.HasPrefix(p, "!") {
+			prefix, p = "!", p[1:]
+		}
+		expanded, err := glob.ExpandBraces(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid exclude pattern %q", prefix+p)
+		}
+		for _, e := range expanded {
+			out = append(out, prefix+e)
+		}
+	}
+	return out, nil
+}
+
 func fileToTemplates(inFile, outFile string, mode os.FileMode, modeOverride bool) (*tplate, error) {
 	if inFile != "-" {
 		si, err := fs.Stat(inFile)
@@ -303,3 +303,16 @@ type bufferCloser struct {
 func (b *bufferCloser) Close() error {
 	return nil
 }
+
+func TestExpandExcludes(t *testing.T) {
+	out, err := expandExcludes(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, out)
+
+	out, err = expandExcludes([]string{"*.{png,jpg}", "!**/*.{yaml,yml}", "vendor/"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"*.png", "*.jpg", "!**/*.yaml", "!**/*.yml", "vendor/"}, out)
+
+	_, err = expandExcludes([]string{"*.{png"})
+	assert.Error(t, err)
+}
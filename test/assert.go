@@ -0,0 +1,120 @@
+package test
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// AssertEqual - fail unless the values are equal. Numbers of different types
+// (e.g. an int and a float64 parsed from JSON) are equal when their values are.
+func AssertEqual(message string, expected, actual interface{}) (string, error) {
+	if !equal(expected, actual) {
+		return "", failure(message, "expected %#v, got %#v", expected, actual)
+	}
+	return "", nil
+}
+
+// AssertType - fail unless the value has the given type. The type can be one
+// of "string", "bool", "int", "float", "number", "map", "slice", or "nil", or
+// a Go type name like "[]string".
+func AssertType(message, typ string, value interface{}) (string, error) {
+	if !isType(typ, value) {
+		return "", failure(message, "expected a value of type %s, got %T", typ, value)
+	}
+	return "", nil
+}
+
+// AssertMatches - fail unless the value matches the regular expression
+func AssertMatches(message, expression, value string) (string, error) {
+	re, err := regexp.Compile(expression)
+	if err != nil {
+		return "", err
+	}
+	if !re.MatchString(value) {
+		return "", failure(message, "%q does not match %q", value, expression)
+	}
+	return "", nil
+}
+
+// AssertOneOf - fail unless the value is equal to one of the allowed values
+func AssertOneOf(message string, allowed []interface{}, value interface{}) (string, error) {
+	for _, a := range allowed {
+		if equal(a, value) {
+			return "", nil
+		}
+	}
+	s := make([]string, len(allowed))
+	for i, a := range allowed {
+		s[i] = fmt.Sprintf("%#v", a)
+	}
+	return "", failure(message, "expected one of [%s], got %#v", strings.Join(s, ", "), value)
+}
+
+func failure(message, format string, args ...interface{}) error {
+	detail := fmt.Sprintf(format, args...)
+	if message != "" {
+		return errors.Errorf("assertion failed: %s (%s)", message, detail)
+	}
+	return errors.Errorf("assertion failed: %s", detail)
+}
+
+func equal(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	x, ok := toFloat(a)
+	if !ok {
+		return false
+	}
+	y, ok := toFloat(b)
+	return ok && x == y
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	r := reflect.ValueOf(v)
+	switch r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(r.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(r.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return r.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func isType(typ string, value interface{}) bool {
+	if value == nil {
+		return typ == "nil"
+	}
+	k := reflect.TypeOf(value).Kind()
+	switch typ {
+	case "string":
+		return k == reflect.String
+	case "bool":
+		return k == reflect.Bool
+	case "int":
+		switch k {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return true
+		}
+		return false
+	case "float":
+		return k == reflect.Float32 || k == reflect.Float64
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	case "map":
+		return k == reflect.Map
+	case "slice":
+		return k == reflect.Slice || k == reflect.Array
+	default:
+		return fmt.Sprintf("%T", value) == typ
+	}
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertEqual(t *testing.T) {
+	_, err := AssertEqual("", "foo", "foo")
+	assert.NoError(t, err)
+	_, err = AssertEqual("", 8080, float64(8080))
+	assert.NoError(t, err)
+	_, err = AssertEqual("", []interface{}{"a", 1}, []interface{}{"a", 1})
+	assert.NoError(t, err)
+
+	_, err = AssertEqual("", 8080, 80)
+	assert.EqualError(t, err, "assertion failed: expected 8080, got 80")
+	_, err = AssertEqual("port must be 8080", 8080, "8080")
+	assert.EqualError(t, err, `assertion failed: port must be 8080 (expected 8080, got "8080")`)
+}
+
+func TestAssertType(t *testing.T) {
+	testdata := []struct {
+		typ   string
+		value interface{}
+		ok    bool
+	}{
+		{"string", "foo", true},
+		{"string", 1, false},
+		{"bool", false, true},
+		{"int", int64(1), true},
+		{"int", 1.5, false},
+		{"float", 1.5, true},
+		{"number", 1, true},
+		{"number", 1.5, true},
+		{"number", "1", false},
+		{"map", map[string]interface{}{}, true},
+		{"slice", []string{}, true},
+		{"slice", [2]int{}, true},
+		{"slice", "foo", false},
+		{"nil", nil, true},
+		{"string", nil, false},
+		{"[]string", []string{}, true},
+		{"[]string", []interface{}{}, false},
+	}
+	for _, d := range testdata {
+		_, err := AssertType("", d.typ, d.value)
+		if d.ok {
+			assert.NoError(t, err, "%s %#v", d.typ, d.value)
+		} else {
+			assert.Error(t, err, "%s %#v", d.typ, d.value)
+		}
+	}
+
+	_, err := AssertType("", "map", []string{})
+	assert.EqualError(t, err, "assertion failed: expected a value of type map, got []string")
+}
+
+func TestAssertMatches(t *testing.T) {
+	_, err := AssertMatches("", `^v\d+$`, "v12")
+	assert.NoError(t, err)
+
+	_, err = AssertMatches("", `^v\d+$`, "12")
+	assert.EqualError(t, err, `assertion failed: "12" does not match "^v\\d+$"`)
+
+	_, err = AssertMatches("", `[a-`, "12")
+	assert.Error(t, err)
+}
+
+func TestAssertOneOf(t *testing.T) {
+	_, err := AssertOneOf("", []interface{}{"dev", "prod"}, "prod")
+	assert.NoError(t, err)
+	_, err = AssertOneOf("", []interface{}{1, 2}, 2.0)
+	assert.NoError(t, err)
+
+	_, err = AssertOneOf("invalid env", []interface{}{"dev", "prod"}, "qa")
+	assert.EqualError(t, err, `assertion failed: invalid env (expected one of ["dev", "prod"], got "qa")`)
+	_, err = AssertOneOf("", []interface{}{}, "qa")
+	assert.Error(t, err)
+}
@@ -87,6 +87,24 @@ func (s *InputDirSuite) TestInputDir(c *C) {
 	}
 }
 
+func (s *InputDirSuite) TestInputDirWithBraceExclude(c *C) {
+	result := icmd.RunCommand(GomplateBin,
+		"--input-dir", s.tmpDir.Join("in"),
+		"--output-dir", s.tmpDir.Join("out"),
+		"--exclude", "{drei.sh,inner/*}",
+		"-d", "config="+s.tmpDir.Join("config.yml"),
+	)
+	result.Assert(c, icmd.Success)
+
+	files, err := ioutil.ReadDir(s.tmpDir.Join("out"))
+	assert.NilError(c, err)
+	names := []string{}
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.DeepEqual(c, []string{"eins.txt", "vier.txt"}, names)
+}
+
 func (s *InputDirSuite) TestInputDirWithModeOverride(c *C) {
 	result := icmd.RunCommand(GomplateBin,
 		"--input-dir", s.tmpDir.Join("in"),
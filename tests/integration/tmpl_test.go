@@ -23,6 +23,7 @@ func (s *TmplSuite) SetUpTest(c *C) {
 	s.tmpDir = fs.NewDir(c, "gomplate-tmpltests",
 		fs.WithFiles(map[string]string{
 			"toyaml.tmpl": `{{ . | data.ToYAML }}{{"\n"}}`,
+			"lib/header.tmpl": `# {{ .title }} (generated by {{ .tool | strings.ToUpper }})`,
 			"services.yaml": `services:
   - name: users
     config:
@@ -108,3 +109,25 @@ func (s *TmplSuite) TestExec(c *C) {
  "replicas": 18
 }`, string(out))
 }
+
+func (s *TmplSuite) TestInclude(c *C) {
+	result := icmd.RunCmd(icmd.Command(GomplateBin,
+		"-d", "lib="+s.tmpDir.Join("lib")+"/",
+		"-i", `{{ tmpl.Include "lib" "header.tmpl" (dict "title" "Config" "tool" "gomplate") }}`,
+	))
+	result.Assert(c, icmd.Expected{ExitCode: 0, Out: `# Config (generated by GOMPLATE)`})
+
+	result = icmd.RunCmd(icmd.Command(GomplateBin,
+		"-c", "in=stdin:///in.json",
+		"-d", "hdr="+s.tmpDir.Join("lib", "header.tmpl"),
+		"-i", `{{ tmpl.Include "hdr" .in }}`,
+	), func(cmd *icmd.Cmd) {
+		cmd.Stdin = bytes.NewBufferString(`{"title":"Hi","tool":"me"}`)
+	})
+	result.Assert(c, icmd.Expected{ExitCode: 0, Out: `# Hi (generated by ME)`})
+
+	result = icmd.RunCmd(icmd.Command(GomplateBin,
+		"-i", `{{ tmpl.Include "nope" }}`,
+	))
+	result.Assert(c, icmd.Expected{ExitCode: 1, Err: `Undefined datasource 'nope'`})
+}
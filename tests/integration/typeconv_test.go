@@ -71,6 +71,19 @@ Languages are: {{ join $c.lang " and " }}`,
 		"Languages are: C and Go and COBOL")
 }
 
+func (s *TypeconvSuite) TestCSVOptions(c *C) {
+	inOutTest(c, `{{ $opts := dict "delimiter" ";" "comment" "#" -}}
+{{ $c := "# export\nname;price\nWidget;1,50\nGadget;2,00\n" | csvByRow $opts -}}
+{{ range $c }}{{ .name }} costs {{ .price }}
+{{ end -}}
+{{ $c | data.ToCSV (dict "delimiter" ";" "crlf" false "quoteAll" true) }}`,
+		`Widget costs 1,50
+Gadget costs 2,00
+"name";"price"
+"Widget";"1,50"
+"Gadget";"2,00"`)
+}
+
 func (s *TypeconvSuite) TestTOML(c *C) {
 	inOutTest(c, `{{ $t := `+"`"+`# comment
 foo = "bar"
@@ -12,11 +12,22 @@ import (
 type Template struct {
 	root       *template.Template
 	defaultCtx interface{}
+	reader     ReaderFunc
 }
 
+// ReaderFunc - a function that reads the raw contents of the named
+// datasource, or of a datasource URL
+type ReaderFunc func(alias string, args ...string) (string, error)
+
 // New -
 func New(root *template.Template, ctx interface{}) *Template {
-	return &Template{root, ctx}
+	return &Template{root: root, defaultCtx: ctx}
+}
+
+// NewWithReader - like New, but templates can also be included from
+// datasources, read with the given reader
+func NewWithReader(root *template.Template, ctx interface{}, reader ReaderFunc) *Template {
+	return &Template{root: root, defaultCtx: ctx, reader: reader}
 }
 
 // Inline - a template function to do inline template processing
@@ -55,6 +66,79 @@ func (t *Template) Exec(name string, tmplcontext ...interface{}) (string, error)
 	return render(tmpl, ctx)
 }
 
+// Include - fetch a template from a datasource and execute it. The template
+// is parsed with the same functions and delimiters as the calling template.
+//
+// Can be called 4 ways:
+// {{ tmpl.Include "alias" }} - the datasource's template with default context
+// {{ tmpl.Include "alias" "sub/path" }} - a template at a sub-path of the datasource, with default context
+// {{ tmpl.Include "alias" $foo }} - the datasource's template with given context
+// {{ tmpl.Include "alias" "sub/path" $foo }} - a template at a sub-path of the datasource, with given context
+func (t *Template) Include(args ...interface{}) (string, error) {
+	alias, subpath, ctx, err := t.parseIncludeArgs(args...)
+	if err != nil {
+		return "", err
+	}
+	if t.reader == nil {
+		return "", errors.Errorf("can not include %s: no datasource reader available", alias)
+	}
+
+	name := alias
+	readArgs := []string{}
+	if subpath != "" {
+		name = alias + "/" + subpath
+		readArgs = append(readArgs, subpath)
+	}
+
+	in, err := t.reader(alias, readArgs...)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read template %s", name)
+	}
+	return t.inline(name, in, ctx)
+}
+
+func (t *Template) parseIncludeArgs(args ...interface{}) (alias, subpath string, ctx interface{}, err error) {
+	ctx = t.defaultCtx
+
+	if len(args) == 0 || len(args) > 3 {
+		return "", "", nil, errors.Errorf("wrong number of args for tmpl.Include: want 1, 2, or 3 - got %d", len(args))
+	}
+	alias, ok := args[0].(string)
+	if !ok {
+		return "", "", nil, errors.Errorf("wrong input: first arg (alias) must be string, got %T", args[0])
+	}
+
+	switch len(args) {
+	case 2:
+		// this can either be (alias, subpath string) or (alias string, ctx interface{})
+		switch second := args[1].(type) {
+		case string:
+			subpath = second
+		default:
+			ctx = second
+		}
+	case 3:
+		subpath, ok = args[1].(string)
+		if !ok {
+			return "", "", nil, errors.Errorf("wrong input: second arg (subpath) must be string, got %T", args[1])
+		}
+		ctx = args[2]
+	}
+
+	return alias, subpath, ctx, nil
+}
+
+// Resolve - look up the named template, or if no template with that name is
+// defined, parse the text as an inline template. This is for functions which
+// render templates as callbacks, and so is not a method (which would make it
+// callable from templates).
+func Resolve(t *Template, nameOrText string) (*template.Template, error) {
+	if tmpl := t.root.Lookup(nameOrText); tmpl != nil {
+		return tmpl, nil
+	}
+	return t.root.New("<inline>").Parse(nameOrText)
+}
+
 func render(tmpl *template.Template, ctx interface{}) (string, error) {
 	out := &bytes.Buffer{}
 	err := tmpl.Execute(out, ctx)
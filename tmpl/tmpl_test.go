@@ -4,6 +4,7 @@ import (
 	"testing"
 	"text/template"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -88,3 +89,87 @@ func TestExec(t *testing.T) {
 	_, err = tmpl.Exec("bogus")
 	assert.Error(t, err)
 }
+
+func TestResolve(t *testing.T) {
+	root := template.New("root")
+	t1 := root.New("T1")
+	t1.Parse("hello, {{ . }}")
+	tmpl := &Template{root: root}
+
+	r, err := Resolve(tmpl, "T1")
+	assert.NoError(t, err)
+	assert.Equal(t, t1, r)
+
+	r, err = Resolve(tmpl, "goodbye, {{ . }}")
+	assert.NoError(t, err)
+	out, err := render(r, "world")
+	assert.NoError(t, err)
+	assert.Equal(t, "goodbye, world", out)
+
+	_, err = Resolve(tmpl, "{{ bogus")
+	assert.Error(t, err)
+}
+
+func TestInclude(t *testing.T) {
+	sources := map[string]string{
+		"hello":         `hello {{ .who }}`,
+		"lib/header.t":  `== {{ . | print }} ==`,
+		"lib/nested.t":  `{{ tmpl.Include "hello" }}!`,
+		"lib/recurse.t": `{{ tmpl.Include "lib" "header.t" "inner" }}`,
+		"broken/bad.t":  `{{ bogus }}`,
+	}
+	reader := func(alias string, args ...string) (string, error) {
+		k := alias
+		if len(args) > 0 {
+			k = alias + "/" + args[0]
+		}
+		s, ok := sources[k]
+		if !ok {
+			return "", errors.Errorf("not found: %s", k)
+		}
+		return s, nil
+	}
+	defaultCtx := map[string]string{"who": "world"}
+	tmpl := NewWithReader(template.New("root"), defaultCtx, reader)
+	tmpl.root.Funcs(template.FuncMap{
+		"tmpl": func() *Template { return tmpl },
+	})
+
+	out, err := tmpl.Include("hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", out)
+
+	out, err = tmpl.Include("hello", map[string]string{"who": "there"})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello there", out)
+
+	out, err = tmpl.Include("lib", "header.t", "title")
+	assert.NoError(t, err)
+	assert.Equal(t, "== title ==", out)
+
+	out, err = tmpl.Include("lib", "nested.t")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world!", out)
+
+	out, err = tmpl.Include("lib", "recurse.t")
+	assert.NoError(t, err)
+	assert.Equal(t, "== inner ==", out)
+
+	_, err = tmpl.Include("missing")
+	assert.Error(t, err)
+
+	_, err = tmpl.Include("broken", "bad.t")
+	assert.Error(t, err)
+
+	_, err = tmpl.Include()
+	assert.Error(t, err)
+
+	_, err = tmpl.Include(42)
+	assert.Error(t, err)
+
+	_, err = tmpl.Include("lib", 42, "ctx")
+	assert.Error(t, err)
+
+	_, err = New(template.New("root"), nil).Include("hello")
+	assert.Error(t, err)
+}